@@ -0,0 +1,39 @@
+package sigctx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"time"
+)
+
+// WithAbortReport makes the context write a crash-style report to w when an
+// Abortive signal, as classified by the severity options, is about to cancel
+// it. The report holds the signal, the time and the stacks of all
+// goroutines. Graceful signals, and signals delivered by Trigger, do not
+// produce a report. Errors writing to w are ignored.
+func WithAbortReport(w io.Writer) Option {
+	return func(o *options) {
+		o.beforeCancel = append(o.beforeCancel, func(c *signalCtx, sig os.Signal) {
+			if c.opts.severityOf(sig) != Abortive {
+				return
+			}
+			fmt.Fprintf(w, "sigctx: received signal %v at %v\n\n", sig, time.Now().Format(time.RFC3339Nano))
+			w.Write(allStacks())
+		})
+	}
+}
+
+// allStacks returns the stacks of all goroutines, as formatted by
+// runtime.Stack.
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,51 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithAbortReport(t *testing.T) {
+	tests := []struct {
+		sig    os.Signal
+		report bool
+	}{
+		{syscall.SIGQUIT, true},
+		{syscall.SIGTERM, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sig.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			var n fakeNotifier
+			c, stop := New(context.Background(), []os.Signal{tt.sig}, n.option(), WithAbortReport(&buf))
+			defer stop()
+
+			n.send(tt.sig)
+			select {
+			case <-c.Done():
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for context to be done after %v", tt.sig)
+			}
+			stop()
+
+			report := buf.String()
+			if !tt.report {
+				if report != "" {
+					t.Errorf("got report %q for a graceful signal", report)
+				}
+				return
+			}
+			if !strings.Contains(report, "goroutine") || !strings.Contains(report, tt.sig.String()) {
+				t.Errorf("report = %q, want goroutine stacks and the signal", report)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+)
+
+// An Action is what a context returned by NotifyContextMap does when a
+// signal arrives.
+type Action int
+
+const (
+	// Cancel cancels the context, as with NotifyContext.
+	Cancel Action = iota
+	// Reload sends the signal on the reloads channel, as with
+	// NotifyContextReload, and leaves the context alone.
+	Reload
+	// Ignore diverts the signal from its default behavior but does nothing
+	// with it.
+	Ignore
+	// ForceExit exits the process at once with status ForceExitCode, without
+	// canceling the context, as a second Ctrl+C does with
+	// NotifyContextForceExit.
+	ForceExit
+)
+
+func (a Action) String() string {
+	switch a {
+	case Cancel:
+		return "cancel"
+	case Reload:
+		return "reload"
+	case Ignore:
+		return "ignore"
+	case ForceExit:
+		return "force exit"
+	}
+	return "unknown"
+}
+
+// NotifyContextMap is like NotifyContextReload, but each signal in handlers
+// gets its own Action, so that, for example, SIGINT and SIGTERM cancel the
+// context, SIGHUP is sent on reloads and SIGQUIT exits at once. Signals
+// missing from handlers are not registered and keep their default behavior;
+// in particular, unlike NotifyContext, an empty map diverts no signal at
+// all. handlers is copied, and may be modified once NotifyContextMap
+// returns.
+//
+// Unlike with NotifyContextReload, the actions keep applying once the
+// context is done, until stop is called: reload signals are still sent on
+// reloads, ForceExit still exits, and only the signals that cancel the
+// context are counted by ImpatientCount.
+func NotifyContextMap(parent context.Context, handlers map[os.Signal]Action) (ctx context.Context, reloads <-chan os.Signal, stop context.CancelFunc) {
+	actions := make(map[os.Signal]Action, len(handlers))
+	var signals, reloadSignals []os.Signal
+	for sig, a := range handlers {
+		actions[sig] = a
+		if a == Reload {
+			reloadSignals = append(reloadSignals, sig)
+		} else {
+			signals = append(signals, sig)
+		}
+	}
+	sortSignals(signals)
+	sortSignals(reloadSignals)
+	act := func(sig os.Signal) bool {
+		switch actions[sig] {
+		case Ignore:
+			return false
+		case ForceExit:
+			osExit(ForceExitCode)
+			return false
+		}
+		return true
+	}
+	r := newReloader()
+	opts := []Option{func(o *options) {
+		o.gates = append(o.gates, func(sig os.Signal, _ time.Time) bool {
+			return act(sig)
+		})
+		// Reload signals that arrive once the context is done no longer go
+		// through the gate of newReloadCtx.
+		o.lateGates = append(o.lateGates, func(sig os.Signal) bool {
+			if actions[sig] == Reload {
+				r.send(sig)
+				return false
+			}
+			return act(sig)
+		})
+	}}
+	if len(actions) == 0 {
+		opts = append(opts, WithNotifier(
+			func(chan<- os.Signal, ...os.Signal) {},
+			func(chan<- os.Signal) {},
+		))
+	}
+	return newReloadCtx(parent, r, reloadSignals, signals, opts)
+}
+
+// sortSignals sorts signals by name, so that the signals taken from a map
+// are listed in a stable order.
+func sortSignals(signals []os.Signal) {
+	sort.Slice(signals, func(i, j int) bool {
+		return signals[i].String() < signals[j].String()
+	})
+}
@@ -0,0 +1,127 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextMap(t *testing.T) {
+	codes := withExit(t)
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	handlers := map[os.Signal]Action{
+		syscall.SIGTERM: Cancel,
+		syscall.SIGHUP:  Reload,
+		syscall.SIGALRM: Ignore,
+		syscall.SIGQUIT: ForceExit,
+	}
+	c, reloads, stop := NotifyContextMap(context.Background(), handlers)
+	defer stop()
+	delete(handlers, syscall.SIGTERM)
+
+	n.send(syscall.SIGHUP)
+	select {
+	case sig := <-reloads:
+		if sig != syscall.SIGHUP {
+			t.Errorf("reloaded on %v, want %v", sig, syscall.SIGHUP)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a reload")
+	}
+
+	n.send(syscall.SIGALRM)
+	n.send(syscall.SIGQUIT)
+	select {
+	case code := <-codes:
+		if code != ForceExitCode {
+			t.Errorf("exited with %d, want %d", code, ForceExitCode)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGQUIT to exit")
+	}
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v before SIGTERM, want nil", err)
+	}
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGTERM to cancel the context")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
+
+func TestNotifyContextMapAfterCancel(t *testing.T) {
+	codes := withExit(t)
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	c, reloads, stop := NotifyContextMap(context.Background(), map[os.Signal]Action{
+		syscall.SIGTERM: Cancel,
+		syscall.SIGHUP:  Reload,
+		syscall.SIGALRM: Ignore,
+		syscall.SIGQUIT: ForceExit,
+	})
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGTERM to cancel the context")
+	}
+
+	n.send(syscall.SIGHUP)
+	select {
+	case sig := <-reloads:
+		if sig != syscall.SIGHUP {
+			t.Errorf("reloaded on %v, want %v", sig, syscall.SIGHUP)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a reload after the context is done")
+	}
+
+	for _, sig := range []os.Signal{syscall.SIGALRM, syscall.SIGTERM, syscall.SIGQUIT} {
+		for !n.send(sig) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	select {
+	case code := <-codes:
+		if code != ForceExitCode {
+			t.Errorf("exited with %d, want %d", code, ForceExitCode)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGQUIT to exit after the context is done")
+	}
+	if got := ImpatientCount(c); got != 1 {
+		t.Errorf("ImpatientCount(c) = %d, want 1 for the second SIGTERM only", got)
+	}
+}
+
+func TestNotifyContextMapEmpty(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	_, _, stop := NotifyContextMap(context.Background(), nil)
+	defer stop()
+	if n.ch != nil {
+		t.Errorf("expected an empty map not to register any signal")
+	}
+}
@@ -0,0 +1,35 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// NotifyContextAll is like NotifyContext, but the returned context is
+// canceled by the first signal of any kind, as signal.Notify does when it is
+// given no signals. This suits short-lived command line tools that should
+// give up on whatever signal they get.
+//
+// Signals that the runtime or the terminal raise as part of normal operation
+// rather than to stop the program do not cancel the context: on Unix, these
+// are SIGURG, which the Go runtime uses to preempt goroutines, SIGCHLD, sent
+// when a child process exits, SIGWINCH, sent when the terminal is resized,
+// SIGPIPE, raised by a write to a closed pipe or socket, SIGPROF, raised by
+// the CPU profiler, SIGCONT, sent when a stopped process resumes, and SIGIO,
+// raised when asynchronous I/O is possible.
+func NotifyContextAll(parent context.Context) (ctx context.Context, stop context.CancelFunc) {
+	return newSignalCtx(parent, nil, []Option{withAllSignals})
+}
+
+func withAllSignals(o *options) {
+	o.all = true
+	o.gates = append(o.gates, func(sig os.Signal, _ time.Time) bool {
+		for _, s := range ignoredSignals {
+			if s == sig {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,9 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package sigctx
+
+import "os"
+
+// ignoredSignals do not cancel a context returned by NotifyContextAll.
+var ignoredSignals = []os.Signal{}
@@ -0,0 +1,20 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"os"
+	"syscall"
+)
+
+// ignoredSignals do not cancel a context returned by NotifyContextAll.
+var ignoredSignals = []os.Signal{
+	syscall.SIGURG,
+	syscall.SIGCHLD,
+	syscall.SIGWINCH,
+	syscall.SIGPIPE,
+	syscall.SIGPROF,
+	syscall.SIGCONT,
+	syscall.SIGIO,
+}
@@ -0,0 +1,81 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextAll(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		t.Run(sig.String(), func(t *testing.T) {
+			c, stop := NotifyContextAll(context.Background())
+			defer stop()
+
+			syscall.Kill(syscall.Getpid(), sig)
+			select {
+			case <-c.Done():
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for %v", sig)
+			}
+			if got, _ := Signal(c); got != os.Signal(sig) {
+				t.Errorf("Signal(c) = %v, want %v", got, sig)
+			}
+		})
+	}
+}
+
+func TestNotifyContextAllIgnored(t *testing.T) {
+	c, stop := NotifyContextAll(context.Background())
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGURG)
+	syscall.Kill(syscall.Getpid(), syscall.SIGWINCH)
+	select {
+	case <-c.Done():
+		t.Fatalf("context canceled by %v", context.Cause(c))
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGTERM")
+	}
+}
+
+func TestNotifyContextAllBrokenPipe(t *testing.T) {
+	c, stop := NotifyContextAll(context.Background())
+	defer stop()
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Close(fds[0])
+	syscall.Close(fds[1])
+
+	if _, err := syscall.Write(fds[0], []byte("x")); err != syscall.EPIPE {
+		t.Fatalf("Write to a closed socket = %v, want %v", err, syscall.EPIPE)
+	}
+	select {
+	case <-c.Done():
+		t.Fatalf("context canceled by %v", context.Cause(c))
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNotifyContextAllString(t *testing.T) {
+	c, stop := NotifyContextAll(context.Background())
+	defer stop()
+	if got := c.(interface{ String() string }).String(); !strings.HasSuffix(got, ", [all])") {
+		t.Errorf("String() = %q, want the signals rendered as [all]", got)
+	}
+}
@@ -0,0 +1,31 @@
+package sigctx
+
+import (
+	"os"
+	"time"
+)
+
+// unknownSender is the SenderPID of an AuditEntry whose sender is unknown.
+const unknownSender = -1
+
+// An AuditEntry records what canceled a context by a signal.
+type AuditEntry struct {
+	Signal os.Signal
+
+	// SenderPID is the process ID of the sender of the signal. The signal
+	// package does not expose the siginfo of the signals it delivers, so the
+	// sender is never known and SenderPID is always -1, including for
+	// signals delivered by Trigger.
+	SenderPID int
+
+	Time time.Time
+}
+
+// WithAuditSink makes the context pass an AuditEntry to sink when a signal
+// cancels it, so that services can record what initiated their shutdown.
+// sink is called right after the context is canceled.
+func WithAuditSink(sink func(AuditEntry)) Option {
+	return func(o *options) {
+		o.auditSink = sink
+	}
+}
@@ -0,0 +1,38 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithAuditSink(t *testing.T) {
+	entries := make(chan AuditEntry, 1)
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithAuditSink(func(e AuditEntry) {
+		entries <- e
+	}))
+	defer stop()
+
+	start := time.Now()
+	n.send(syscall.SIGTERM)
+	select {
+	case e := <-entries:
+		if e.Signal != syscall.SIGTERM {
+			t.Errorf("entry Signal = %v, want %v", e.Signal, syscall.SIGTERM)
+		}
+		if e.SenderPID != -1 {
+			t.Errorf("entry SenderPID = %d, want -1", e.SenderPID)
+		}
+		if e.Time.Before(start) {
+			t.Errorf("entry Time = %v, want after %v", e.Time, start)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for an audit entry")
+	}
+	if err := c.Err(); err == nil {
+		t.Errorf("expected the context to be done when the entry is recorded")
+	}
+}
@@ -0,0 +1,15 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func BenchmarkConstructStop(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, stop := NotifyContext(context.Background(), os.Interrupt)
+		stop()
+	}
+}
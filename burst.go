@@ -0,0 +1,37 @@
+package sigctx
+
+import (
+	"os"
+	"time"
+)
+
+// WithBurst makes the context cancel only when count of the listed signals
+// arrive within window of the first one, for example to quit on a quick
+// triple Ctrl+C. A signal that arrives more than window after the first of a
+// burst starts a new burst.
+//
+// The signal package drops signals that arrive while the previous one has
+// not been handled yet, so signals sent in a very tight loop may count once.
+func WithBurst(count int, window time.Duration) Option {
+	return func(o *options) {
+		b := &burst{count: count, window: window}
+		o.gates = append(o.gates, b.allow)
+	}
+}
+
+type burst struct {
+	count  int
+	window time.Duration
+
+	n     int
+	start time.Time
+}
+
+func (b *burst) allow(_ os.Signal, now time.Time) bool {
+	if b.n == 0 || now.Sub(b.start) > b.window {
+		b.n = 0
+		b.start = now
+	}
+	b.n++
+	return b.n >= b.count
+}
@@ -0,0 +1,59 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// sendSignals sends n signals to the current process, waiting for the
+// context to receive each of them and then for interval.
+func sendSignals(t *testing.T, ctx context.Context, sig syscall.Signal, n int, interval time.Duration) {
+	t.Helper()
+	events := Events(ctx)
+	for i := 0; i < n; i++ {
+		syscall.Kill(syscall.Getpid(), sig)
+		select {
+		case e := <-events:
+			if e.Type != Received {
+				t.Fatalf("got %v event, want %v", e.Type, Received)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for signal %d to be received", i)
+		}
+		time.Sleep(interval)
+	}
+}
+
+func TestWithBurst(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, WithBurst(3, time.Second))
+	defer stop()
+
+	sendSignals(t, c, syscall.SIGINT, 2, 0)
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v after two signals, want nil", err)
+	}
+	sendSignals(t, c, syscall.SIGINT, 1, 0)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for context to be done after three signals")
+	}
+}
+
+func TestWithBurstTooSlow(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, WithBurst(3, 20*time.Millisecond))
+	defer stop()
+
+	sendSignals(t, c, syscall.SIGINT, 3, 50*time.Millisecond)
+	select {
+	case <-c.Done():
+		t.Errorf("expected signals slower than the window not to cancel the context")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
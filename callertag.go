@@ -0,0 +1,32 @@
+package sigctx
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+)
+
+// WithCallerTag tags the context with the call site of WithCallerTag, in
+// the form "file:line", so that an unexpected shutdown can be attributed to
+// the code that created the context. The tag is returned by CallerTag, and
+// included in the logs of WithLogger and in the String of the context. It
+// is only captured when the option is given.
+func WithCallerTag() Option {
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		return func(*options) {}
+	}
+	tag := file + ":" + strconv.Itoa(line)
+	return func(o *options) {
+		o.callerTag = tag
+	}
+}
+
+// CallerTag returns the call site recorded by WithCallerTag, or "" if ctx
+// has no signal context or it was created without the option.
+func CallerTag(ctx context.Context) string {
+	if c, ok := fromContext(ctx); ok {
+		return c.opts.callerTag
+	}
+	return ""
+}
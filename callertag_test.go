@@ -0,0 +1,58 @@
+package sigctx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestCallerTag(t *testing.T) {
+	var h recordingHandler
+	_, file, line, _ := runtime.Caller(0)
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithCallerTag(), WithLogger(slog.New(&h)))
+	defer stop()
+
+	want := file + ":" + strconv.Itoa(line+1)
+	if got := CallerTag(c); got != want {
+		t.Errorf("CallerTag(c) = %q, want %q", got, want)
+	}
+	if got := fmt.Sprint(c); !strings.HasSuffix(got, " created at "+want) {
+		t.Errorf("String() = %q, want the caller tag %q", got, want)
+	}
+
+	Trigger(c, syscall.SIGTERM)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.records) == 0 {
+		t.Fatalf("expected the signal to be logged")
+	}
+	for _, r := range h.records {
+		var caller string
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "caller" {
+				caller = a.Value.String()
+			}
+			return true
+		})
+		if caller != want {
+			t.Errorf("%q logged with caller %q, want %q", r.Message, caller, want)
+		}
+	}
+}
+
+func TestCallerTagUnset(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+	if got := CallerTag(c); got != "" {
+		t.Errorf("CallerTag(c) = %q, want empty", got)
+	}
+	if got := CallerTag(context.Background()); got != "" {
+		t.Errorf("CallerTag(context.Background()) = %q, want empty", got)
+	}
+}
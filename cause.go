@@ -0,0 +1,139 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NotifyContextCause is like NotifyContext, for callers that rely on
+// context.Cause to tell a signal from other reasons for cancellation: once
+// one of the listed signals arrives, context.Cause returns a *SignalError
+// for it, while Err still returns context.Canceled. Contexts returned by
+// NotifyContext keep context.Canceled as their cause, as with
+// signal.NotifyContext.
+func NotifyContextCause(parent context.Context, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
+	return newSignalCtx(parent, signals, []Option{withSignalCause})
+}
+
+// withSignalCause makes a signal cancel the context with a *SignalError as
+// its cause.
+func withSignalCause(o *options) {
+	o.signalCause = true
+}
+
+// A SignalError is the cause of a context returned by NotifyContextCause or
+// New that was canceled by a signal, as returned by context.Cause. It wraps
+// context.Canceled.
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return "sigctx: received signal " + e.Signal.String()
+}
+
+func (e *SignalError) Unwrap() error { return context.Canceled }
+
+// Is reports whether target is ErrSignal or a *SignalError for the same
+// signal.
+func (e *SignalError) Is(target error) bool {
+	if target == ErrSignal {
+		return true
+	}
+	t, ok := target.(*SignalError)
+	return ok && t.Signal == e.Signal
+}
+
+// IsSignal reports whether err, or an error it wraps, is a *SignalError for
+// sig.
+func IsSignal(err error, sig os.Signal) bool {
+	return errors.Is(err, &SignalError{Signal: sig})
+}
+
+// AnySignal returns the signal of the first *SignalError found in err's
+// tree, or false if there is none.
+func AnySignal(err error) (os.Signal, bool) {
+	var se *SignalError
+	if !errors.As(err, &se) {
+		return nil, false
+	}
+	return se.Signal, true
+}
+
+// ErrParentDone describes a context canceled because its parent was done.
+var ErrParentDone = errors.New("sigctx: parent context done")
+
+// EncodeCause returns a compact string form of a cancellation cause, to be
+// written to a log field or sent to another process and turned back into an
+// error with DecodeCause. Signals are encoded with their number where known,
+// so decoding is only meaningful on the same platform.
+func EncodeCause(err error) string {
+	var se *SignalError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &se):
+		return "signal:" + encodeSignal(se.Signal)
+	case errors.Is(err, ErrStopped):
+		return "stopped"
+	case errors.Is(err, ErrParentDone):
+		return "parent"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return "error:" + err.Error()
+}
+
+// DecodeCause returns the error encoded by EncodeCause. Signals are decoded
+// as a *SignalError; ErrStopped, ErrParentDone, context.Canceled and
+// context.DeadlineExceeded are decoded as themselves, and any other error as
+// an error with the same message.
+func DecodeCause(s string) error {
+	switch s {
+	case "":
+		return nil
+	case "stopped":
+		return ErrStopped
+	case "parent":
+		return ErrParentDone
+	case "deadline":
+		return context.DeadlineExceeded
+	case "canceled":
+		return context.Canceled
+	}
+	if rest, ok := strings.CutPrefix(s, "signal:"); ok {
+		return &SignalError{Signal: decodeSignal(rest)}
+	}
+	return errors.New(strings.TrimPrefix(s, "error:"))
+}
+
+// encodeSignal returns sig as its number, if known, and name separated by a
+// colon.
+func encodeSignal(sig os.Signal) string {
+	num := ""
+	if n, ok := signalNumber(sig); ok {
+		num = strconv.Itoa(n)
+	}
+	return num + ":" + sig.String()
+}
+
+// decodeSignal returns the signal encoded by encodeSignal.
+func decodeSignal(s string) os.Signal {
+	num, name, _ := strings.Cut(s, ":")
+	if n, err := strconv.Atoi(num); err == nil {
+		return numberedSignal(n, name)
+	}
+	return namedSignal(name)
+}
+
+// namedSignal is a decoded signal whose number is unknown.
+type namedSignal string
+
+func (s namedSignal) Signal() {}
+
+func (s namedSignal) String() string { return string(s) }
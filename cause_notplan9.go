@@ -0,0 +1,25 @@
+//go:build !plan9
+// +build !plan9
+
+package sigctx
+
+import (
+	"os"
+	"syscall"
+)
+
+// numberedSignal returns the signal with number n.
+func numberedSignal(n int, _ string) os.Signal {
+	return syscall.Signal(n)
+}
+
+// signalNumber returns the number of sig, if it has one.
+func signalNumber(sig os.Signal) (int, bool) {
+	switch s := sig.(type) {
+	case syscall.Signal:
+		return int(s), true
+	case interface{ Number() int }:
+		return s.Number(), true
+	}
+	return 0, false
+}
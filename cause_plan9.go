@@ -0,0 +1,17 @@
+package sigctx
+
+import "os"
+
+// numberedSignal returns the signal with number n. Plan 9 notes are strings
+// rather than numbers, so the signal is decoded by name.
+func numberedSignal(_ int, name string) os.Signal {
+	return namedSignal(name)
+}
+
+// signalNumber returns the number of sig, if it has one.
+func signalNumber(sig os.Signal) (int, bool) {
+	if s, ok := sig.(interface{ Number() int }); ok {
+		return s.Number(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,103 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestEncodeCauseSignal(t *testing.T) {
+	s := EncodeCause(&SignalError{Signal: syscall.SIGTERM})
+	err := DecodeCause(s)
+	if !errors.Is(err, &SignalError{Signal: syscall.SIGTERM}) {
+		t.Errorf("DecodeCause(%q) = %v, want SIGTERM", s, err)
+	}
+	if errors.Is(err, &SignalError{Signal: syscall.SIGINT}) {
+		t.Errorf("DecodeCause(%q) = %v matches SIGINT", s, err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DecodeCause(%q) = %v, want it to wrap context.Canceled", s, err)
+	}
+	var se *SignalError
+	if !errors.As(err, &se) || se.Signal != syscall.SIGTERM {
+		t.Errorf("DecodeCause(%q) = %v, want a *SignalError for SIGTERM", s, err)
+	}
+}
+
+func TestEncodeCause(t *testing.T) {
+	for _, cause := range []error{
+		ErrStopped,
+		ErrParentDone,
+		context.Canceled,
+		context.DeadlineExceeded,
+		&SignalError{Signal: namedSignal("unnumbered")},
+	} {
+		s := EncodeCause(cause)
+		if got := DecodeCause(s); !errors.Is(got, cause) {
+			t.Errorf("DecodeCause(%q) = %v, want %v", s, got, cause)
+		}
+	}
+	if got := DecodeCause(EncodeCause(nil)); got != nil {
+		t.Errorf("DecodeCause(EncodeCause(nil)) = %v, want nil", got)
+	}
+
+	s := EncodeCause(errors.New("boom"))
+	if got := DecodeCause(s); got == nil || got.Error() != "boom" {
+		t.Errorf("DecodeCause(%q) = %v, want an error with message boom", s, got)
+	}
+	if got := EncodeCause(newStopTraceError()); got != "stopped" {
+		t.Errorf("EncodeCause(*StopTraceError) = %q, want %q", got, "stopped")
+	}
+}
+
+func TestSignalCause(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option())
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	cause := context.Cause(c)
+	if !errors.Is(cause, &SignalError{Signal: syscall.SIGTERM}) {
+		t.Errorf("context.Cause(c) = %v, want SIGTERM", cause)
+	}
+	if got := DecodeCause(EncodeCause(cause)); !errors.Is(got, cause) {
+		t.Errorf("DecodeCause(EncodeCause(%v)) = %v", cause, got)
+	}
+}
+
+func TestIsSignal(t *testing.T) {
+	err := fmt.Errorf("shutting down: %w", &SignalError{Signal: syscall.SIGTERM})
+	if !IsSignal(err, syscall.SIGTERM) {
+		t.Errorf("IsSignal(%v, SIGTERM) = false, want true", err)
+	}
+	if IsSignal(err, syscall.SIGINT) {
+		t.Errorf("IsSignal(%v, SIGINT) = true, want false", err)
+	}
+	if IsSignal(ErrStopped, syscall.SIGTERM) {
+		t.Errorf("IsSignal(ErrStopped, SIGTERM) = true, want false")
+	}
+	if IsSignal(nil, syscall.SIGTERM) {
+		t.Errorf("IsSignal(nil, SIGTERM) = true, want false")
+	}
+}
+
+func TestAnySignal(t *testing.T) {
+	err := fmt.Errorf("shutting down: %w", &SignalError{Signal: syscall.SIGINT})
+	if sig, ok := AnySignal(err); !ok || sig != syscall.SIGINT {
+		t.Errorf("AnySignal(%v) = %v, %v, want SIGINT, true", err, sig, ok)
+	}
+	for _, err := range []error{nil, ErrStopped, context.Canceled, errors.New("boom")} {
+		if sig, ok := AnySignal(err); ok {
+			t.Errorf("AnySignal(%v) = %v, true, want false", err, sig)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package sigctx
+
+import "context"
+
+// recordCause records the cause of a trigger that fired, unless c already
+// settled, so that triggers firing long after the cancellation are not
+// reported by AllCauses.
+func (c *signalCtx) recordCause(cause error) {
+	if cause == nil {
+		cause = context.Canceled
+	}
+	if c.settled.Load() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, err := range c.causes {
+		if err == cause {
+			return
+		}
+	}
+	c.causes = append(c.causes, cause)
+}
+
+// AllCauses returns, for diagnostics, the causes of every trigger that fired
+// to cancel the signal context of ctx, such as a signal, its parent, its stop
+// function if called before ctx was done, or an option like
+// WithDependencyCheck. When several triggers fire at nearly the same time,
+// only the cause of the one that wins, as described in WithReasonPriority,
+// is returned by Cause; the others are nevertheless listed here.
+//
+// The cause returned by Cause always comes first, followed by the
+// others in the order they fired. The list is best-effort: triggers that fire
+// well after the cancellation are not listed, and one that fires as the
+// context finishes handling the cancellation may be missed. AllCauses returns
+// nil if ctx was not created by this package or is not done.
+func AllCauses(ctx context.Context) []error {
+	c, ok := fromContext(ctx)
+	if !ok || c.Err() == nil {
+		return nil
+	}
+	winner := Cause(c)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	causes := []error{winner}
+	for _, err := range c.causes {
+		if err != winner {
+			causes = append(causes, err)
+		}
+	}
+	return causes
+}
@@ -0,0 +1,72 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+var errParentGone = errors.New("parent gone")
+
+func TestAllCauses(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		parent, cancelParent := context.WithCancelCause(context.Background())
+		timeout, cancelTimeout := context.WithCancelCause(context.Background())
+		c, stop := New(parent, []os.Signal{syscall.SIGTERM})
+		LinkDeadline(c, timeout)
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			Trigger(c, syscall.SIGTERM)
+		}()
+		go func() {
+			defer wg.Done()
+			cancelParent(errParentGone)
+		}()
+		go func() {
+			defer wg.Done()
+			cancelTimeout(context.DeadlineExceeded)
+		}()
+		wg.Wait()
+		<-c.Done()
+
+		winner := Cause(c)
+		all := AllCauses(c)
+		if len(all) == 0 || all[0] != winner {
+			t.Fatalf("AllCauses(c) = %v, want %v first", all, winner)
+		}
+		stop()
+		if got := Cause(c); got != winner {
+			t.Fatalf("Cause(c) = %v after stop, want %v", got, winner)
+		}
+		for _, err := range AllCauses(c) {
+			if err == context.Canceled {
+				t.Errorf("AllCauses(c) lists the deferred stop: %v", AllCauses(c))
+			}
+		}
+		cancelParent(nil)
+		cancelTimeout(nil)
+	}
+}
+
+func TestAllCausesSingle(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+	if all := AllCauses(c); all != nil {
+		t.Errorf("AllCauses(c) = %v before cancellation, want nil", all)
+	}
+	Trigger(c, syscall.SIGTERM)
+	stop()
+	all := AllCauses(c)
+	if len(all) != 1 || !IsSignal(all[0], syscall.SIGTERM) {
+		t.Errorf("AllCauses(c) = %v, want only the signal", all)
+	}
+	if all := AllCauses(context.Background()); all != nil {
+		t.Errorf("AllCauses(context.Background()) = %v, want nil", all)
+	}
+}
@@ -0,0 +1,45 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// signalFeedBuffer is the capacity of the channel returned by
+// NotifyContextChan.
+const signalFeedBuffer = 16
+
+// NotifyContextChan is like NotifyContext, but also returns a channel on
+// which every delivery of the listed signals is observed, so that callers can
+// keep handling signals, such as SIGHUP reloads, after the first one canceled
+// the context.
+//
+// The channel does not compete with the context for the signals: each signal
+// the context receives is sent on it, in the order received, starting with
+// the one that cancels the context, which is sent before Done is closed.
+// Signals keep being sent after the context is done, until stop is called,
+// which closes the channel. Like the signal package, the context does not
+// block on the channel: signals are dropped when it already holds 16 of them.
+func NotifyContextChan(parent context.Context, signals ...os.Signal) (ctx context.Context, ch <-chan os.Signal, stop context.CancelFunc) {
+	return newSignalCtxChan(parent, signals, nil)
+}
+
+func newSignalCtxChan(parent context.Context, signals []os.Signal, opts []Option) (*signalCtx, <-chan os.Signal, context.CancelFunc) {
+	feed := make(chan os.Signal, signalFeedBuffer)
+	// Subscribe before the context starts watching, so that no signal is
+	// missed.
+	opts = append(opts, func(o *options) {
+		o.setups = append(o.setups, func(c *signalCtx) {
+			c.mu.Lock()
+			c.events.signals = feed
+			c.mu.Unlock()
+		})
+	})
+	c, stop := newSignalCtx(parent, signals, opts)
+	// The setup does not run if the parent is already done, but stop still
+	// has to close feed.
+	c.mu.Lock()
+	c.events.signals = feed
+	c.mu.Unlock()
+	return c, feed, stop
+}
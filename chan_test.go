@@ -0,0 +1,49 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextChan(t *testing.T) {
+	var n fakeNotifier
+	c, ch, stop := newSignalCtxChan(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, []Option{n.option()})
+	defer stop()
+
+	want := []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGINT}
+	for i, sig := range want {
+		n.send(sig)
+		select {
+		case got := <-ch:
+			if got != sig {
+				t.Errorf("delivery %d = %v, want %v", i, got, sig)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for delivery %d", i)
+		}
+		if i == 0 {
+			<-c.Done()
+		}
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGINT {
+		t.Errorf("Signal(c) = %v, %v, want the first signal %v", sig, ok, syscall.SIGINT)
+	}
+
+	stop()
+	if _, ok := <-ch; ok {
+		t.Errorf("expected the channel to be closed after stop")
+	}
+}
+
+func TestNotifyContextChanParentDone(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, ch, stop := NotifyContextChan(parent, syscall.SIGINT)
+	stop()
+	if _, ok := <-ch; ok {
+		t.Errorf("expected the channel to be closed after stop")
+	}
+}
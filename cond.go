@@ -0,0 +1,29 @@
+package sigctx
+
+import "sync"
+
+// WithCond bridges cancellation to code waiting on a condition variable.
+// When the context is done, cond.L is locked, *flag is set to true unless
+// flag is nil, cond.L is unlocked, and cond.Broadcast is called.
+//
+// Waiters must hold cond.L and check the flag, or the context, in their wait
+// loop, as with any condition:
+//
+//	cond.L.Lock()
+//	for !ready && !canceled {
+//		cond.Wait()
+//	}
+//	cond.L.Unlock()
+func WithCond(cond *sync.Cond, flag *bool) Option {
+	return func(o *options) {
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			cond.L.Lock()
+			if flag != nil {
+				*flag = true
+			}
+			cond.L.Unlock()
+			cond.Broadcast()
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithCond(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		canceled bool
+	)
+	cond := sync.NewCond(&mu)
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithCond(cond, &canceled))
+	defer stop()
+
+	woken := make(chan bool)
+	waiting := make(chan struct{})
+	go func() {
+		mu.Lock()
+		close(waiting)
+		for !canceled {
+			cond.Wait()
+		}
+		flag := canceled
+		mu.Unlock()
+		woken <- flag
+	}()
+
+	<-waiting
+	Trigger(c, syscall.SIGTERM)
+	select {
+	case flag := <-woken:
+		if !flag {
+			t.Errorf("waiter observed the flag unset")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the waiter to wake up")
+	}
+}
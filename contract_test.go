@@ -0,0 +1,139 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestContract checks that every way of canceling a signal context honors
+// the context.Context contract: Err returns context.Canceled or
+// context.DeadlineExceeded, while context.Cause carries the details.
+func TestContract(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   func(t *testing.T) context.Context
+		err   error
+		cause func(error) bool
+	}{
+		{
+			name: "signal",
+			ctx: func(t *testing.T) context.Context {
+				c, stop := NotifyContext(context.Background(), syscall.SIGTERM)
+				t.Cleanup(stop)
+				Trigger(c, syscall.SIGTERM)
+				return c
+			},
+			err:   context.Canceled,
+			cause: func(err error) bool { return err == context.Canceled },
+		},
+		{
+			name: "signal cause",
+			ctx: func(t *testing.T) context.Context {
+				c, stop := NotifyContextCause(context.Background(), syscall.SIGTERM)
+				t.Cleanup(stop)
+				Trigger(c, syscall.SIGTERM)
+				return c
+			},
+			err:   context.Canceled,
+			cause: func(err error) bool { return IsSignal(err, syscall.SIGTERM) },
+		},
+		{
+			name: "stop",
+			ctx: func(t *testing.T) context.Context {
+				c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithStopTrace())
+				stop()
+				return c
+			},
+			err:   context.Canceled,
+			cause: func(err error) bool { return errors.Is(err, ErrStopped) },
+		},
+		{
+			name: "parent",
+			ctx: func(t *testing.T) context.Context {
+				parent, cancel := context.WithCancelCause(context.Background())
+				c, stop := NotifyContext(parent, syscall.SIGTERM)
+				t.Cleanup(stop)
+				cancel(errMaintenance)
+				return c
+			},
+			err:   context.Canceled,
+			cause: func(err error) bool { return err == errMaintenance },
+		},
+		{
+			name: "timeout",
+			ctx: func(t *testing.T) context.Context {
+				parent, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+				t.Cleanup(cancel)
+				c, stop := NotifyContext(parent, syscall.SIGTERM)
+				t.Cleanup(stop)
+				return c
+			},
+			err:   context.DeadlineExceeded,
+			cause: func(err error) bool { return err == context.DeadlineExceeded },
+		},
+		{
+			name: "deadline",
+			ctx: func(t *testing.T) context.Context {
+				parent, cancel := context.WithDeadlineCause(context.Background(), time.Now().Add(time.Millisecond), errMaintenance)
+				t.Cleanup(cancel)
+				c, stop := NotifyContext(parent, syscall.SIGTERM)
+				t.Cleanup(stop)
+				return c
+			},
+			err:   context.DeadlineExceeded,
+			cause: func(err error) bool { return err == errMaintenance },
+		},
+		{
+			name: "grace",
+			ctx: func(t *testing.T) context.Context {
+				c, stop := NotifyContextTimeout(context.Background(), time.Millisecond, syscall.SIGTERM)
+				t.Cleanup(stop)
+				Trigger(c, syscall.SIGTERM)
+				select {
+				case <-GraceExpired(c):
+				case <-time.After(time.Second):
+					t.Fatalf("timed out waiting for the grace period to expire")
+				}
+				return GraceContext(c)
+			},
+			err:   context.DeadlineExceeded,
+			cause: func(err error) bool { return err == context.DeadlineExceeded },
+		},
+		{
+			name: "memory",
+			ctx: func(t *testing.T) context.Context {
+				oldInterval, oldRead := memCheckInterval, readMemUsage
+				memCheckInterval = time.Millisecond
+				readMemUsage = func() uint64 { return 2 }
+				c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithOOMGuard(1))
+				t.Cleanup(func() {
+					stop()
+					memCheckInterval, readMemUsage = oldInterval, oldRead
+				})
+				return c
+			},
+			err:   context.Canceled,
+			cause: func(err error) bool { return err == ErrMemoryPressure },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.ctx(t)
+			select {
+			case <-c.Done():
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for context to be done")
+			}
+			if got := c.Err(); got != tt.err {
+				t.Errorf("c.Err() = %v, want %v", got, tt.err)
+			}
+			if got := context.Cause(c); !tt.cause(got) {
+				t.Errorf("context.Cause(c) = %v, which is not the expected cause", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package sigctx
+
+import (
+	"context"
+	"time"
+)
+
+// WithCountdown makes the channel returned by Countdown receive the time
+// remaining out of total every tick once a signal cancels the context,
+// starting with total itself, for example to display how long a shutdown
+// may still take. A total of zero or less counts down the grace period set
+// with WithGracePeriod, if any. The channel is closed when total has
+// elapsed, when stop is called, or right away if the context is canceled by
+// its parent or its stop function, in which case nothing is counted down.
+// Values are dropped rather than delayed if the receiver falls behind.
+//
+// WithCountdown panics if tick is not positive.
+func WithCountdown(total, tick time.Duration) Option {
+	if tick <= 0 {
+		panic("sigctx: non-positive tick for WithCountdown")
+	}
+	return func(o *options) {
+		ch := make(chan time.Duration, 1)
+		o.countdown = ch
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			defer close(ch)
+			<-c.Done()
+			if _, ok := Signal(c); !ok {
+				return
+			}
+			total := total
+			if total <= 0 && c.opts.grace != nil {
+				total = c.opts.grace.d
+			}
+			deadline := time.Now().Add(total)
+			t := time.NewTicker(tick)
+			defer t.Stop()
+			for remaining := total; remaining > 0; remaining = time.Until(deadline) {
+				select {
+				case ch <- remaining:
+				default:
+				}
+				select {
+				case <-t.C:
+				case <-c.stoppingChan():
+					return
+				}
+			}
+		})
+	}
+}
+
+// Countdown returns the channel of the countdown set with WithCountdown for
+// the signal context of ctx. If ctx has no signal context or no countdown,
+// the returned channel never receives a value.
+func Countdown(ctx context.Context) <-chan time.Duration {
+	if c, ok := fromContext(ctx); ok {
+		return c.opts.countdown
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// drainCountdown returns the values received from ch until it is closed.
+func drainCountdown(t *testing.T, ch <-chan time.Duration) []time.Duration {
+	t.Helper()
+	var got []time.Duration
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case d, ok := <-ch:
+			if !ok {
+				return got
+			}
+			got = append(got, d)
+		case <-timeout:
+			t.Fatalf("timed out waiting for the countdown to finish, got %v", got)
+		}
+	}
+}
+
+func TestWithCountdown(t *testing.T) {
+	var n fakeNotifier
+	const total = 50 * time.Millisecond
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithCountdown(total, 10*time.Millisecond))
+	defer stop()
+
+	countdown := Countdown(c)
+	select {
+	case d := <-countdown:
+		t.Fatalf("received %v before the context was done", d)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	n.send(syscall.SIGTERM)
+	got := drainCountdown(t, countdown)
+	if len(got) < 2 {
+		t.Fatalf("got %v, want at least two values", got)
+	}
+	if got[0] != total {
+		t.Errorf("first value = %v, want %v", got[0], total)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] >= got[i-1] || got[i] <= 0 {
+			t.Errorf("values %v are not positive and decreasing", got)
+			break
+		}
+	}
+}
+
+func TestWithCountdownGracePeriod(t *testing.T) {
+	var n fakeNotifier
+	const grace = 30 * time.Millisecond
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithGracePeriod(grace), WithCountdown(0, 10*time.Millisecond))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	got := drainCountdown(t, Countdown(c))
+	if len(got) == 0 || got[0] != grace {
+		t.Errorf("got %v, want a countdown starting at %v", got, grace)
+	}
+}
+
+func TestWithCountdownStop(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithCountdown(time.Hour, time.Millisecond))
+	stop()
+	if got := drainCountdown(t, Countdown(c)); len(got) != 0 {
+		t.Errorf("got %v after stop, want no values", got)
+	}
+}
+
+func TestWithCountdownStopDuringCountdown(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithCountdown(time.Hour, time.Millisecond))
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	stop()
+	// The countdown would last an hour if stop did not end it.
+	drainCountdown(t, Countdown(c))
+}
+
+func TestWithCountdownParentCanceled(t *testing.T) {
+	var n fakeNotifier
+	parent, cancel := context.WithCancel(context.Background())
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, n.option(), WithCountdown(time.Hour, time.Millisecond))
+	defer stop()
+	cancel()
+	if got := drainCountdown(t, Countdown(c)); len(got) != 0 {
+		t.Errorf("got %v after the parent was canceled, want no values", got)
+	}
+
+	c, stop = New(parent, []os.Signal{syscall.SIGTERM}, n.option(), WithCountdown(time.Hour, time.Millisecond))
+	defer stop()
+	if got := drainCountdown(t, Countdown(c)); len(got) != 0 {
+		t.Errorf("got %v for a parent done at construction, want no values", got)
+	}
+}
+
+func TestWithCountdownInvalidTick(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithCountdown did not panic for a zero tick")
+		}
+	}()
+	WithCountdown(time.Second, 0)
+}
@@ -0,0 +1,23 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// NotifyContextDeadline is like NotifyContext, but the returned context is
+// also canceled at deadline, whichever of the deadline and the signals comes
+// first, which suits batch jobs that must end at a fixed time but still stop
+// on Ctrl+C. Its Deadline method reports deadline, or that of parent if it
+// is earlier. Once the deadline passes, Err and context.Cause return
+// context.DeadlineExceeded and CancelReason returns ReasonParent, while a
+// signal is reported as with NotifyContext.
+func NotifyContextDeadline(parent context.Context, deadline time.Time, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
+	dctx, cancel := context.WithDeadline(parent, deadline)
+	c, stopSignals := newSignalCtx(dctx, signals, nil)
+	return c, func() {
+		stopSignals()
+		cancel()
+	}
+}
@@ -0,0 +1,58 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextDeadline(t *testing.T) {
+	deadline := time.Now().Add(10 * time.Millisecond)
+	c, stop := NotifyContextDeadline(context.Background(), deadline, syscall.SIGTERM)
+	defer stop()
+
+	if got, ok := c.Deadline(); !ok || !got.Equal(deadline) {
+		t.Errorf("c.Deadline() = %v, %v, want %v, true", got, ok, deadline)
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the deadline")
+	}
+	if err := c.Err(); err != context.DeadlineExceeded {
+		t.Errorf("c.Err() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if cause := context.Cause(c); cause != context.DeadlineExceeded {
+		t.Errorf("context.Cause(c) = %v, want %v", cause, context.DeadlineExceeded)
+	}
+	if sig, ok := Signal(c); ok {
+		t.Errorf("Signal(c) = %v, true after the deadline", sig)
+	}
+}
+
+func TestNotifyContextDeadlineSignal(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	c, stop := NotifyContextDeadline(context.Background(), deadline, syscall.SIGTERM)
+	defer stop()
+
+	Trigger(c, syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGTERM")
+	}
+	if got, ok := c.Deadline(); !ok || !got.Equal(deadline) {
+		t.Errorf("c.Deadline() = %v, %v, want %v, true", got, ok, deadline)
+	}
+	if err := c.Err(); err != context.Canceled {
+		t.Errorf("c.Err() = %v, want %v", err, context.Canceled)
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+	if errors.Is(context.Cause(c), context.DeadlineExceeded) {
+		t.Errorf("context.Cause(c) matches %v after a signal", context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,38 @@
+package sigctx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDependencyFailed is the cause of a context canceled because the check
+// given to WithDependencyCheck failed. The cause also wraps the error
+// returned by the check.
+var ErrDependencyFailed = errors.New("sigctx: dependency check failed")
+
+// WithDependencyCheck makes the context also cancel when check returns an
+// error, so that a service can terminate, and be restarted by its
+// orchestrator, when a critical dependency becomes unreachable. check is
+// called every interval, starting one interval after the context is created,
+// and the cause of the cancellation matches both ErrDependencyFailed and the
+// returned error with errors.Is.
+func WithDependencyCheck(check func() error, interval time.Duration) Option {
+	return func(o *options) {
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			t := time.NewTicker(interval)
+			defer t.Stop()
+			for {
+				select {
+				case <-c.Done():
+					return
+				case <-t.C:
+				}
+				if err := check(); err != nil {
+					c.cancel(fmt.Errorf("%w: %w", ErrDependencyFailed, err))
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errUnreachable = errors.New("config server unreachable")
+
+func TestWithDependencyCheck(t *testing.T) {
+	var calls atomic.Int32
+	check := func() error {
+		if calls.Add(1) > 2 {
+			return errUnreachable
+		}
+		return nil
+	}
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithDependencyCheck(check, time.Millisecond))
+	defer stop()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after the check failed")
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("check called %d times, want 3", n)
+	}
+	cause := context.Cause(c)
+	if !errors.Is(cause, ErrDependencyFailed) || !errors.Is(cause, errUnreachable) {
+		t.Errorf("context.Cause(c) = %v, want %v wrapping %v", cause, ErrDependencyFailed, errUnreachable)
+	}
+	if err := c.Err(); err != context.Canceled {
+		t.Errorf("c.Err() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWithDependencyCheckPassing(t *testing.T) {
+	var calls atomic.Int32
+	check := func() error {
+		calls.Add(1)
+		return nil
+	}
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithDependencyCheck(check, time.Millisecond))
+	defer stop()
+
+	select {
+	case <-c.Done():
+		t.Fatalf("context canceled by a passing check: %v", context.Cause(c))
+	case <-time.After(20 * time.Millisecond):
+	}
+	if calls.Load() == 0 {
+		t.Errorf("check was never called")
+	}
+}
@@ -0,0 +1,20 @@
+package sigctx
+
+import (
+	"os"
+	"time"
+)
+
+// WithDistinctThreshold makes the context cancel only once n distinct
+// listed signals have been received, for example both SIGINT and SIGTERM,
+// as a deliberate confirmation of the shutdown. Receiving the same signal
+// again does not count. Unlike WithBurst, there is no time window.
+func WithDistinctThreshold(n int) Option {
+	return func(o *options) {
+		seen := make(map[os.Signal]bool)
+		o.gates = append(o.gates, func(sig os.Signal, _ time.Time) bool {
+			seen[sig] = true
+			return len(seen) >= n
+		})
+	}
+}
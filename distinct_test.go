@@ -0,0 +1,34 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithDistinctThreshold(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, n.option(), WithDistinctThreshold(2))
+	defer stop()
+	events := Events(c)
+
+	for i := 0; i < 2; i++ {
+		n.send(syscall.SIGINT)
+		waitReceived(t, events)
+	}
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v after SIGINT twice, want nil", err)
+	}
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
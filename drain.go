@@ -0,0 +1,54 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// NotifyContextDrain is like NotifyContext, but shuts down in two phases:
+// the first of the listed signals to arrive closes the drain channel,
+// telling workers to stop accepting new work while they finish the work in
+// flight, and only a second one cancels the context. The drain channel is
+// also closed once the context is done for any other reason, such as its
+// parent being done or stop being called, so that waiting on drain never
+// outlives the context.
+func NotifyContextDrain(parent context.Context, signals ...os.Signal) (ctx context.Context, drain <-chan struct{}, stop context.CancelFunc) {
+	d := &drainer{ch: make(chan struct{})}
+	c, stop := newSignalCtx(parent, signals, []Option{func(o *options) {
+		o.gates = append(o.gates, d.gate)
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			d.close()
+		})
+	}})
+	if c.Err() != nil {
+		// The watchers do not run if the parent is already done.
+		d.close()
+	}
+	return c, d.ch, stop
+}
+
+// drainer closes ch on the first signal of a context created by
+// NotifyContextDrain.
+type drainer struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+// gate lets a signal cancel the context once ch is closed, and closes it
+// otherwise.
+func (d *drainer) gate(os.Signal, time.Time) bool {
+	select {
+	case <-d.ch:
+		return true
+	default:
+		d.close()
+		return false
+	}
+}
+
+func (d *drainer) close() {
+	d.once.Do(func() { close(d.ch) })
+}
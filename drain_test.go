@@ -0,0 +1,66 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextDrain(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	c, drain, stop := NotifyContextDrain(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-drain:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the first SIGTERM to close drain")
+	}
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v after the first SIGTERM, want nil", err)
+	}
+
+	for !n.send(syscall.SIGTERM) {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the second SIGTERM to cancel the context")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
+
+func TestNotifyContextDrainStop(t *testing.T) {
+	c, drain, stop := NotifyContextDrain(context.Background(), os.Interrupt)
+	stop()
+	select {
+	case <-drain:
+	default:
+		t.Errorf("expected stop to close drain")
+	}
+	if c.Err() == nil {
+		t.Errorf("expected stop to cancel the context")
+	}
+}
+
+func TestNotifyContextDrainParent(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, drain, stop := NotifyContextDrain(parent, os.Interrupt)
+	defer stop()
+	select {
+	case <-drain:
+	default:
+		t.Errorf("expected drain to be closed when the parent is already done")
+	}
+}
@@ -0,0 +1,36 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithDurationSink(t *testing.T) {
+	var (
+		n     fakeNotifier
+		calls int
+		got   time.Duration
+	)
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithDurationSink(func(d time.Duration) {
+		calls++
+		got = d
+	}))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	const shutdown = 20 * time.Millisecond
+	time.Sleep(shutdown)
+	stop()
+	stop()
+
+	if calls != 1 {
+		t.Errorf("sink was called %d times, want 1", calls)
+	}
+	if got < shutdown {
+		t.Errorf("sink received %v, want at least %v", got, shutdown)
+	}
+}
@@ -0,0 +1,100 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// ErrAllSignals is returned by AddSignal and RemoveSignal for a context
+// that listens to every signal, because it was given none.
+var ErrAllSignals = errors.New("sigctx: context listens to every signal")
+
+// AddSignal makes the signal context of ctx also listen to sig, for example
+// to only honor SIGUSR2 once a program finished its initialization. It has
+// no effect if the context already listens to sig, or was stopped. It
+// returns ErrNotSignalContext if ctx has no signal context, and
+// ErrAllSignals if the context listens to every signal.
+func AddSignal(ctx context.Context, sig os.Signal) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.allSignals {
+		return ErrAllSignals
+	}
+	if wants(c.signals, sig) {
+		return nil
+	}
+	c.signals = append(c.signals[:len(c.signals):len(c.signals)], sig)
+	if c.diverted && !c.stopped {
+		c.opts.notify(c.in, c.signals...)
+	}
+	return nil
+}
+
+// RemoveSignal makes the signal context of ctx stop listening to sig, which
+// regains its default behavior as with the stop function, while the other
+// signals stay registered throughout. Removing the last signal leaves the
+// context listening to none, rather than to every signal. RemoveSignal has
+// no effect if the context does not listen to sig, or was stopped. It
+// returns ErrNotSignalContext if ctx has no signal context, and
+// ErrAllSignals if the context listens to every signal.
+func RemoveSignal(ctx context.Context, sig os.Signal) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.allSignals {
+		return ErrAllSignals
+	}
+	if !wants(c.signals, sig) {
+		return nil
+	}
+	remaining := make([]os.Signal, 0, len(c.signals)-1)
+	for _, s := range c.signals {
+		if s != sig {
+			remaining = append(remaining, s)
+		}
+	}
+	if c.diverted && !c.stopped {
+		c.reregister(remaining)
+	}
+	c.signals = remaining
+	return nil
+}
+
+// reregister replaces the signals c.in is registered for with signals. The
+// notifier can only unregister a channel for all of its signals at once, so
+// signals are registered on a bridge channel for the time c.in is not, and
+// a signal caught on it is handed over to c.in.
+func (c *signalCtx) reregister(signals []os.Signal) {
+	if len(signals) == 0 {
+		c.opts.stopNotify(c.in)
+		return
+	}
+	bridge := make(chan os.Signal, 1)
+	c.opts.notify(bridge, signals...)
+	c.opts.stopNotify(c.in)
+	c.opts.notify(c.in, signals...)
+	c.opts.stopNotify(bridge)
+	select {
+	case sig := <-bridge:
+		select {
+		case c.in <- sig:
+		default:
+		}
+	default:
+	}
+}
+
+// currentSignals returns the signals c listens to.
+func (c *signalCtx) currentSignals() []os.Signal {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.signals
+}
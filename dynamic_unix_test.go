@@ -0,0 +1,87 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAddSignal(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGUSR1)
+	defer stop()
+
+	if err := AddSignal(c, syscall.SIGUSR2); err != nil {
+		t.Fatalf("AddSignal = %v", err)
+	}
+	if want, got := "signal.NotifyContext(context.Background, [user defined signal 1 user defined signal 2])", fmt.Sprint(c); got != want {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the added signal")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGUSR2 {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGUSR2)
+	}
+}
+
+func TestRemoveSignal(t *testing.T) {
+	// Keep SIGUSR2 from terminating the test once the context unregisters it.
+	other := make(chan os.Signal, 1)
+	signal.Notify(other, syscall.SIGUSR2)
+	defer signal.Stop(other)
+
+	c, stop := NotifyContext(context.Background(), syscall.SIGUSR1, syscall.SIGUSR2)
+	defer stop()
+
+	if err := RemoveSignal(c, syscall.SIGUSR2); err != nil {
+		t.Fatalf("RemoveSignal = %v", err)
+	}
+	if want, got := "signal.NotifyContext(context.Background, [user defined signal 1])", fmt.Sprint(c); got != want {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGUSR2")
+	}
+	select {
+	case <-c.Done():
+		t.Fatalf("context canceled by the removed signal")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the remaining signal")
+	}
+}
+
+func TestAddRemoveSignalErrors(t *testing.T) {
+	c, stop := NotifyContext(context.Background())
+	defer stop()
+	if err := AddSignal(c, syscall.SIGUSR1); err != ErrAllSignals {
+		t.Errorf("AddSignal on a context listening to every signal = %v, want %v", err, ErrAllSignals)
+	}
+	if err := RemoveSignal(c, syscall.SIGUSR1); err != ErrAllSignals {
+		t.Errorf("RemoveSignal on a context listening to every signal = %v, want %v", err, ErrAllSignals)
+	}
+	if err := AddSignal(context.Background(), syscall.SIGUSR1); err != ErrNotSignalContext {
+		t.Errorf("AddSignal(context.Background()) = %v, want %v", err, ErrNotSignalContext)
+	}
+	if err := RemoveSignal(context.Background(), syscall.SIGUSR1); err != ErrNotSignalContext {
+		t.Errorf("RemoveSignal(context.Background()) = %v, want %v", err, ErrNotSignalContext)
+	}
+}
@@ -0,0 +1,48 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// escalation is set by WithEscalation. Its state is only used by the
+// goroutine watching the signals.
+type escalation struct {
+	window time.Duration
+	fn     func()
+
+	// last is the time of the previous signal, or zero if the next one
+	// starts a new window.
+	last time.Time
+}
+
+// NotifyContextEscalate is like NotifyContext for the single signal sig,
+// with WithEscalation: the first delivery of sig cancels the context, and a
+// second one within window calls escalate, for example to exit the process
+// or to force a shutdown that is taking too long.
+func NotifyContextEscalate(parent context.Context, sig os.Signal, window time.Duration, escalate func()) (ctx context.Context, stop context.CancelFunc) {
+	return newSignalCtx(parent, []os.Signal{sig}, []Option{WithEscalation(window, escalate)})
+}
+
+// WithEscalation calls fn, on the goroutine watching the signals, when a
+// listed signal arrives after the context is done and within window of the
+// previous one, starting with the signal that canceled the context. A
+// signal that arrives later than that starts a new window instead, and so
+// does the one after an escalation. Calling stop disarms it.
+func WithEscalation(window time.Duration, fn func()) Option {
+	return func(o *options) {
+		o.escalation = &escalation{window: window, fn: fn}
+	}
+}
+
+// arrive records a signal that arrived at now, and reports whether it
+// escalates.
+func (e *escalation) arrive(now time.Time) bool {
+	if !e.last.IsZero() && now.Sub(e.last) <= e.window {
+		e.last = time.Time{}
+		return true
+	}
+	e.last = now
+	return false
+}
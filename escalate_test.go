@@ -0,0 +1,83 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextEscalate(t *testing.T) {
+	var n fakeNotifier
+	escalated := make(chan struct{}, 1)
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(),
+		WithEscalation(time.Second, func() { escalated <- struct{}{} }))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	for !n.send(syscall.SIGTERM) {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-escalated:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a quick second SIGTERM to escalate")
+	}
+}
+
+func TestNotifyContextEscalateWindow(t *testing.T) {
+	var n fakeNotifier
+	escalated := make(chan struct{}, 1)
+	const window = 100 * time.Millisecond
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(),
+		WithEscalation(window, func() { escalated <- struct{}{} }))
+	defer stop()
+	events := Events(c)
+
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	waitReceived(t, events)
+
+	// A second signal after the window starts a new one instead.
+	time.Sleep(2 * window)
+	n.send(syscall.SIGTERM)
+	waitReceived(t, events)
+	select {
+	case <-escalated:
+		t.Fatalf("a SIGTERM arriving after the window escalated")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-escalated:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a SIGTERM within the new window to escalate")
+	}
+}
+
+func TestNotifyContextEscalateParent(t *testing.T) {
+	var n fakeNotifier
+	escalated := make(chan struct{}, 1)
+	parent, cancel := context.WithCancel(context.Background())
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, n.option(),
+		WithEscalation(time.Second, func() { escalated <- struct{}{} }))
+	defer stop()
+	events := Events(c)
+
+	cancel()
+	<-c.Done()
+	// The first signal after the parent canceled the context only starts
+	// a window.
+	for !n.send(syscall.SIGTERM) {
+		time.Sleep(time.Millisecond)
+	}
+	waitReceived(t, events)
+	select {
+	case <-escalated:
+		t.Errorf("the first SIGTERM escalated after the parent canceled the context")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
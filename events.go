@@ -0,0 +1,153 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// EventType identifies a stage in the lifecycle of a signal context.
+type EventType int
+
+const (
+	// Received is emitted when one of the listed signals arrives.
+	Received EventType = iota + 1
+
+	// Canceled is emitted when the context is canceled by a signal or by
+	// its parent.
+	Canceled
+
+	// Stopped is emitted when the stop function is called. It is always the
+	// last event; the channel is closed right after it.
+	Stopped
+)
+
+func (t EventType) String() string {
+	switch t {
+	case Received:
+		return "received"
+	case Canceled:
+		return "canceled"
+	case Stopped:
+		return "stopped"
+	}
+	return "unknown"
+}
+
+// An Event describes something that happened to a signal context.
+type Event struct {
+	Type EventType
+
+	// Signal is the signal that arrived. It is only set for Received events.
+	Signal os.Signal
+
+	Time time.Time
+}
+
+// eventBuffer is the capacity of each channel returned by Events. It is
+// larger than the number of events a context normally emits so that the
+// listener goroutine never waits for a slow reader.
+const eventBuffer = 16
+
+type eventSubscribers struct {
+	chans  []chan Event
+	closed bool
+
+	// signals receives the signal of every Received event, for
+	// NotifyContextChan.
+	signals chan os.Signal
+}
+
+// Events returns a channel on which the lifecycle events of ctx are
+// delivered, in order, from the time of the call. The channel is closed
+// after the Stopped event, so the caller must eventually call the stop
+// function of ctx for a range over the channel to end.
+//
+// Events are sent without blocking; a reader that falls more than a few
+// events behind misses events, but always observes the channel being closed.
+// If ctx was not created by this package, or it was already stopped, the
+// returned channel is closed.
+func Events(ctx context.Context) <-chan Event {
+	ch := make(chan Event, eventBuffer)
+	c, ok := fromContext(ctx)
+	if !ok {
+		close(ch)
+		return ch
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events.closed {
+		close(ch)
+		return ch
+	}
+	c.events.chans = append(c.events.chans, ch)
+	return ch
+}
+
+func (c *signalCtx) publish(e Event) {
+	if e.Type == Received {
+		c.delivered.Add(1)
+		if m := c.opts.metrics; m != nil {
+			m.SignalReceived(e.Signal)
+		}
+		if c.opts.isTerminal(e.Signal) {
+			c.exitRequested.Store(true)
+		}
+	}
+	c.mu.Lock()
+	c.events.send(e)
+	c.mu.Unlock()
+	c.logEvent(e)
+}
+
+// publishCanceled publishes the Canceled event, once.
+func (c *signalCtx) publishCanceled() {
+	e := Event{Type: Canceled, Time: time.Now()}
+	c.mu.Lock()
+	if c.canceled {
+		c.mu.Unlock()
+		return
+	}
+	c.canceled = true
+	c.events.send(e)
+	c.mu.Unlock()
+	if m := c.opts.metrics; m != nil {
+		m.ContextCancelled()
+	}
+	c.logEvent(e)
+}
+
+// closeEvents sends the terminal event e and closes every subscriber.
+func (c *signalCtx) closeEvents(e Event) {
+	c.mu.Lock()
+	if c.events.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.events.send(e)
+	for _, ch := range c.events.chans {
+		close(ch)
+	}
+	c.events.chans = nil
+	if c.events.signals != nil {
+		close(c.events.signals)
+	}
+	c.events.closed = true
+	c.mu.Unlock()
+	c.logEvent(e)
+}
+
+func (s *eventSubscribers) send(e Event) {
+	for _, ch := range s.chans {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+	if e.Type == Received && s.signals != nil {
+		select {
+		case s.signals <- e.Signal:
+		default:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGINT)
+	defer stop()
+
+	events := Events(c)
+	syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGINT")
+	}
+	stop()
+
+	var got []Event
+	for e := range events {
+		got = append(got, e)
+	}
+	want := []EventType{Received, Canceled, Stopped}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events %v, want %v", len(got), got, want)
+	}
+	for i, e := range got {
+		if e.Type != want[i] {
+			t.Errorf("event %d: Type = %v, want %v", i, e.Type, want[i])
+		}
+		if e.Time.IsZero() {
+			t.Errorf("event %d: Time is zero", i)
+		}
+	}
+	if got[0].Signal != syscall.SIGINT {
+		t.Errorf("Received event Signal = %v, want %v", got[0].Signal, syscall.SIGINT)
+	}
+}
+
+func TestEventsStop(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGINT)
+	events := Events(c)
+	stop()
+
+	e, ok := <-events
+	if !ok || e.Type != Stopped {
+		t.Errorf("first event = %v, %v, want %v", e.Type, ok, Stopped)
+	}
+	if _, ok := <-events; ok {
+		t.Errorf("expected events channel to be closed after Stopped")
+	}
+	if _, ok := <-Events(c); ok {
+		t.Errorf("expected Events of a stopped context to be closed")
+	}
+}
+
+func TestEventsNotSignalContext(t *testing.T) {
+	if _, ok := <-Events(context.Background()); ok {
+		t.Errorf("expected Events of a plain context to be closed")
+	}
+}
@@ -0,0 +1,31 @@
+//go:build windows
+// +build windows
+
+package sigctx_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/johejo/sigctx"
+)
+
+// On Windows, pressing Ctrl+C or Ctrl+Break delivers os.Interrupt, and
+// closing the console window, logging off or shutting down the system
+// delivers syscall.SIGTERM. This example simulates Ctrl+C with Trigger, as
+// sending a console control event would also reach the program that runs it.
+func ExampleNotifyContext_windows() {
+	ctx, stop := sigctx.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sigctx.Trigger(ctx, os.Interrupt)
+
+	<-ctx.Done()
+	sig, _ := sigctx.Signal(ctx)
+	fmt.Println(sig)
+
+	// Output:
+	// interrupt
+}
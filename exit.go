@@ -0,0 +1,32 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// WithTerminalSignals sets the signals that make ExitRequested report true.
+// By default, every listed signal does.
+func WithTerminalSignals(signals ...os.Signal) Option {
+	return func(o *options) {
+		o.terminal = make(map[os.Signal]bool, len(signals))
+		for _, sig := range signals {
+			o.terminal[sig] = true
+		}
+	}
+}
+
+func (o *options) isTerminal(sig os.Signal) bool {
+	return o.terminal == nil || o.terminal[sig]
+}
+
+// ExitRequested reports whether a terminal signal, as set by
+// WithTerminalSignals, was received by the signal context of ctx. Unlike
+// Done, it reports true as soon as the signal arrives, even if an option such
+// as WithBurst holds back the cancellation, so that a user interface can stop
+// offering new work the instant the user presses Ctrl+C. It reports false if
+// ctx was not created by this package.
+func ExitRequested(ctx context.Context) bool {
+	c, ok := fromContext(ctx)
+	return ok && c.exitRequested.Load()
+}
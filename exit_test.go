@@ -0,0 +1,68 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExitRequested(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, n.option(), WithBurst(2, time.Minute))
+	defer stop()
+	events := Events(c)
+
+	if ExitRequested(c) {
+		t.Fatalf("ExitRequested(c) = true before any signal")
+	}
+	n.send(syscall.SIGINT)
+	waitReceived(t, events)
+	if !ExitRequested(c) {
+		t.Errorf("ExitRequested(c) = false after the first signal")
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("c.Err() = %v after the first signal, want nil", err)
+	}
+}
+
+func TestWithTerminalSignals(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, n.option(),
+		WithBurst(2, time.Minute), WithTerminalSignals(syscall.SIGINT))
+	defer stop()
+	events := Events(c)
+
+	n.send(syscall.SIGTERM)
+	waitReceived(t, events)
+	if ExitRequested(c) {
+		t.Errorf("ExitRequested(c) = true after a signal that is not terminal")
+	}
+	n.send(syscall.SIGINT)
+	waitReceived(t, events)
+	if !ExitRequested(c) {
+		t.Errorf("ExitRequested(c) = false after a terminal signal")
+	}
+}
+
+func TestExitRequestedNotSignalContext(t *testing.T) {
+	if ExitRequested(context.Background()) {
+		t.Errorf("ExitRequested(context.Background()) = true")
+	}
+}
+
+// waitReceived waits for a Received event on events.
+func waitReceived(t *testing.T, events <-chan Event) {
+	t.Helper()
+	for {
+		select {
+		case e := <-events:
+			if e.Type == Received {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for a Received event")
+		}
+	}
+}
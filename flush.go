@@ -0,0 +1,47 @@
+package sigctx
+
+import (
+	"context"
+	"sync"
+)
+
+// flushResult holds the result of the flusher given to WithFlusher.
+type flushResult struct {
+	mu  sync.Mutex
+	err error
+}
+
+// WithFlusher makes the context call flush once it is done, with a drain
+// context as for OnShutdownPriority, so that buffered metrics, traces or
+// logs are not lost when the program exits. The stop function waits for
+// flush to return, after which FlushErr reports its error.
+func WithFlusher(flush func(ctx context.Context) error) Option {
+	return func(o *options) {
+		r := &flushResult{}
+		o.flushResult = r
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			ctx, cancel := c.drainContext()
+			defer cancel()
+			err := flush(ctx)
+			r.mu.Lock()
+			r.err = err
+			r.mu.Unlock()
+		})
+	}
+}
+
+// FlushErr returns the error returned by the flusher given to WithFlusher
+// for the signal context of ctx. It returns nil if the flusher has not
+// returned yet, which is guaranteed once the stop function returned, or if
+// ctx has no signal context or no flusher.
+func FlushErr(ctx context.Context) error {
+	c, ok := fromContext(ctx)
+	if !ok || c.opts.flushResult == nil {
+		return nil
+	}
+	r := c.opts.flushResult
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
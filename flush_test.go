@@ -0,0 +1,42 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+var errFlush = errors.New("exporter unavailable")
+
+func TestWithFlusher(t *testing.T) {
+	var called, live bool
+	flush := func(ctx context.Context) error {
+		called = true
+		live = ctx.Err() == nil
+		return errFlush
+	}
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithFlusher(flush))
+	defer stop()
+
+	Trigger(c, syscall.SIGTERM)
+	stop()
+	if !called {
+		t.Fatalf("expected the flusher to be called")
+	}
+	if !live {
+		t.Errorf("expected the flusher to get a live drain context")
+	}
+	if err := FlushErr(c); err != errFlush {
+		t.Errorf("FlushErr(c) = %v, want %v", err, errFlush)
+	}
+}
+
+func TestFlushErrWithoutFlusher(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	stop()
+	if err := FlushErr(c); err != nil {
+		t.Errorf("FlushErr(c) = %v without a flusher, want nil", err)
+	}
+}
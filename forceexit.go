@@ -0,0 +1,32 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// ForceExitCode is the exit status used by NotifyContextForceExit, that of a
+// shell whose foreground job was interrupted by SIGINT.
+const ForceExitCode = 130
+
+// osExit is os.Exit, replaced in tests.
+var osExit = os.Exit
+
+// NotifyContextForceExit is like NotifyContext, but once the context is
+// done, a further delivery of any of the listed signals exits the process
+// with status ForceExitCode: the first Ctrl+C starts a graceful shutdown and
+// the second one kills the program. Calling stop disarms it, like it
+// unregisters the signals.
+func NotifyContextForceExit(parent context.Context, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
+	return New(parent, signals, WithForceExit(ForceExitCode))
+}
+
+// WithForceExit makes the process exit with the given status when one of
+// the listed signals arrives after the context is done, and before stop is
+// called. See NotifyContextForceExit.
+func WithForceExit(code int) Option {
+	return func(o *options) {
+		o.forceExit = true
+		o.exitCode = code
+	}
+}
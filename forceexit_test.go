@@ -0,0 +1,70 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// withExit replaces os.Exit until the end of the test, and returns a channel
+// receiving the exit codes.
+func withExit(t *testing.T) <-chan int {
+	codes := make(chan int, 1)
+	old := osExit
+	osExit = func(code int) { codes <- code }
+	t.Cleanup(func() { osExit = old })
+	return codes
+}
+
+func TestNotifyContextForceExit(t *testing.T) {
+	codes := withExit(t)
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, n.option(), WithForceExit(ForceExitCode))
+	defer stop()
+
+	n.send(syscall.SIGINT)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGINT")
+	}
+	select {
+	case code := <-codes:
+		t.Fatalf("exited with %d after the first signal", code)
+	default:
+	}
+
+	n.send(syscall.SIGTERM)
+	select {
+	case code := <-codes:
+		if code != ForceExitCode {
+			t.Errorf("exited with %d, want %d", code, ForceExitCode)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the second signal to exit")
+	}
+}
+
+func TestNotifyContextForceExitStop(t *testing.T) {
+	codes := withExit(t)
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, n.option(), WithForceExit(3))
+	Trigger(c, syscall.SIGINT)
+	stop()
+
+	// Deliver directly to the channel, as a notifier that is slow to
+	// unregister might.
+	sc := c.(*signalCtx)
+	select {
+	case sc.in <- syscall.SIGINT:
+	default:
+	}
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case code := <-codes:
+		t.Errorf("exited with %d after stop", code)
+	default:
+	}
+}
@@ -0,0 +1,32 @@
+package sigctx
+
+import (
+	"os"
+	"sync"
+)
+
+var globalHooks struct {
+	mu  sync.Mutex
+	fns []func(sig os.Signal)
+}
+
+// RegisterGlobalHook registers fn to be called whenever a signal cancels any
+// context created by this package in the process, including by
+// NotifyContext, so that cross-cutting code such as a metrics package can
+// observe every shutdown without passing options around. Hooks run in the
+// order they were registered, right after the context is canceled. They
+// cannot be unregistered.
+func RegisterGlobalHook(fn func(sig os.Signal)) {
+	globalHooks.mu.Lock()
+	defer globalHooks.mu.Unlock()
+	globalHooks.fns = append(globalHooks.fns, fn)
+}
+
+func runGlobalHooks(sig os.Signal) {
+	globalHooks.mu.Lock()
+	fns := globalHooks.fns
+	globalHooks.mu.Unlock()
+	for _, fn := range fns {
+		fn(sig)
+	}
+}
@@ -0,0 +1,46 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRegisterGlobalHook(t *testing.T) {
+	t.Cleanup(resetGlobalHooks)
+	fired := make(chan os.Signal, 1)
+	RegisterGlobalHook(func(sig os.Signal) {
+		select {
+		case fired <- sig:
+		default:
+		}
+	})
+
+	c, stop := NotifyContext(context.Background(), syscall.SIGUSR2)
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	select {
+	case sig := <-fired:
+		if sig != syscall.SIGUSR2 {
+			t.Errorf("hook got %v, want %v", sig, syscall.SIGUSR2)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the global hook")
+	}
+	if c.Err() == nil {
+		t.Errorf("expected the context to be canceled before the hook ran")
+	}
+}
+
+// resetGlobalHooks unregisters the hooks registered by a test.
+func resetGlobalHooks() {
+	globalHooks.mu.Lock()
+	defer globalHooks.mu.Unlock()
+	globalHooks.fns = nil
+}
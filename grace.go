@@ -0,0 +1,123 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// grace is the state of the grace period set with WithGracePeriod.
+type grace struct {
+	d       time.Duration
+	expired chan struct{}
+
+	mu       sync.Mutex
+	deadline time.Time // zero until the grace period starts
+}
+
+// start starts the grace period, if it has not started yet, and returns its
+// deadline. The signal context calls it as a signal cancels it, before its
+// Done channel is closed, so that GraceContext reports the deadline as soon
+// as the context is done.
+func (g *grace) start() time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.deadline.IsZero() {
+		g.deadline = time.Now().Add(g.d)
+	}
+	return g.deadline
+}
+
+// NotifyContextTimeout is like NotifyContext, but once a signal cancels the
+// context, it grants the program a grace period of d to shut down: if stop
+// has not been called d after the signal, the channel returned by
+// GraceExpired is closed, telling the program to abandon its drain, for
+// example by closing the connections of an HTTP server.
+func NotifyContextTimeout(parent context.Context, d time.Duration, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
+	return New(parent, signals, WithGracePeriod(d))
+}
+
+// WithGracePeriod sets the grace period of the context, as described for
+// NotifyContextTimeout. The period only starts when a signal cancels the
+// context, not when the context is created or canceled for another reason.
+func WithGracePeriod(d time.Duration) Option {
+	return func(o *options) {
+		g := &grace{d: d, expired: make(chan struct{})}
+		o.grace = g
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			if _, ok := Signal(c); !ok {
+				return
+			}
+			t := time.NewTimer(time.Until(g.start()))
+			defer t.Stop()
+			select {
+			case <-t.C:
+				close(g.expired)
+			case <-c.stoppingChan():
+			}
+		})
+	}
+}
+
+// GraceExpired returns a channel that is closed when the grace period of the
+// signal context of ctx, set with NotifyContextTimeout or WithGracePeriod,
+// expires. If ctx has no signal context or no grace period, the returned
+// channel is never closed.
+func GraceExpired(ctx context.Context) <-chan struct{} {
+	if c, ok := fromContext(ctx); ok && c.opts.grace != nil {
+		return c.opts.grace.expired
+	}
+	return nil
+}
+
+// GraceContext returns a context for the work that has to finish within the
+// grace period of the signal context of ctx, set with NotifyContextTimeout or
+// WithGracePeriod. Unlike ctx, which is done as soon as a signal arrives, the
+// returned context is only done when the grace period expires, and its
+// Deadline method reports when that happens, so that context-aware code,
+// such as outgoing RPCs, respects the remaining budget. Like ShutdownContext,
+// it holds the values of ctx under the keys given to WithPropagatedValues.
+//
+// The grace period starts before the Done channel of ctx is closed, so the
+// deadline is known as soon as ctx is done. If the grace period has not
+// started, because ctx was not canceled by a signal yet, or if ctx has no
+// signal context or no grace period, the returned context has no deadline
+// and is never done.
+func GraceContext(ctx context.Context) context.Context {
+	parent := propagatedValues(ctx)
+	c, ok := fromContext(ctx)
+	if !ok || c.opts.grace == nil {
+		return parent
+	}
+	g := c.opts.grace
+	g.mu.Lock()
+	deadline := g.deadline
+	g.mu.Unlock()
+	if deadline.IsZero() {
+		return parent
+	}
+	return &graceCtx{Context: parent, deadline: deadline, expired: g.expired}
+}
+
+// graceCtx is the context returned by GraceContext once the grace period
+// started.
+type graceCtx struct {
+	context.Context
+	deadline time.Time
+	expired  <-chan struct{}
+}
+
+func (c *graceCtx) Deadline() (time.Time, bool) { return c.deadline, true }
+
+func (c *graceCtx) Done() <-chan struct{} { return c.expired }
+
+func (c *graceCtx) Err() error {
+	select {
+	case <-c.expired:
+		return context.DeadlineExceeded
+	default:
+		return nil
+	}
+}
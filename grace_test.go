@@ -0,0 +1,92 @@
+package sigctx
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextTimeout(t *testing.T) {
+	const d = 20 * time.Millisecond
+	c, stop := NotifyContextTimeout(context.Background(), d, syscall.SIGTERM)
+	defer stop()
+
+	// The grace period does not start before the signal.
+	select {
+	case <-GraceExpired(c):
+		t.Fatalf("grace period expired before any signal")
+	case <-time.After(2 * d):
+	}
+
+	start := time.Now()
+	Trigger(c, syscall.SIGTERM)
+	if c.Err() == nil {
+		t.Fatalf("expected the context to be canceled by the signal")
+	}
+	select {
+	case <-GraceExpired(c):
+		if elapsed := time.Since(start); elapsed < d {
+			t.Errorf("grace period expired after %v, want at least %v", elapsed, d)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the grace period to expire")
+	}
+}
+
+func TestNotifyContextTimeoutStop(t *testing.T) {
+	c, stop := NotifyContextTimeout(context.Background(), 10*time.Millisecond, syscall.SIGTERM)
+	Trigger(c, syscall.SIGTERM)
+	stop()
+	select {
+	case <-GraceExpired(c):
+		t.Errorf("grace period expired after stop")
+	case <-time.After(30 * time.Millisecond):
+	}
+}
+
+func TestNotifyContextTimeoutParent(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	c, stop := NotifyContextTimeout(parent, time.Millisecond, syscall.SIGTERM)
+	defer stop()
+	cancel()
+	select {
+	case <-GraceExpired(c):
+		t.Errorf("grace period expired after the parent was canceled")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestGraceContext(t *testing.T) {
+	const d = 20 * time.Millisecond
+	c, stop := NotifyContextTimeout(context.Background(), d, syscall.SIGTERM)
+	defer stop()
+
+	if _, ok := GraceContext(c).Deadline(); ok {
+		t.Errorf("GraceContext(c) has a deadline before any signal")
+	}
+
+	start := time.Now()
+	Trigger(c, syscall.SIGTERM)
+	<-c.Done()
+	gctx := GraceContext(c)
+	deadline, ok := gctx.Deadline()
+	if !ok {
+		t.Fatalf("GraceContext(c) has no deadline once c is done")
+	}
+	if err := gctx.Err(); err != nil {
+		t.Fatalf("GraceContext(c).Err() = %v right after the signal, want nil", err)
+	}
+	if deadline.Before(start.Add(d)) || deadline.After(time.Now().Add(d)) {
+		t.Errorf("grace deadline = %v, want %v after the signal at %v", deadline, d, start)
+	}
+
+	select {
+	case <-gctx.Done():
+		if err := gctx.Err(); err != context.DeadlineExceeded {
+			t.Errorf("GraceContext(c).Err() = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the grace context to be done")
+	}
+}
@@ -0,0 +1,36 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// A Group hands out contexts that are all canceled by the same signals,
+// which are registered once for the whole group. It is created by
+// NotifyGroup.
+type Group struct {
+	c *signalCtx
+}
+
+// NotifyGroup returns a Group whose contexts are canceled when one of the
+// listed signals arrives, when parent is done, or when stop is called,
+// whichever happens first, like the context returned by NotifyContext.
+// Several subsystems can so share a single registration of the signals
+// rather than each calling NotifyContext.
+func NotifyGroup(parent context.Context, signals ...os.Signal) (g *Group, stop context.CancelFunc) {
+	c, stop := newSignalCtx(parent, signals, nil)
+	return &Group{c: c}, stop
+}
+
+// New returns a context for a member of the group, with a cancel function
+// that cancels it alone. Signal, IsStopped and the other functions of this
+// package report on the group for the returned context.
+//
+// New does not start a goroutine: like context.WithCancel, the group keeps
+// a reference to the context until it is canceled, by cancel or by the
+// group, so code that creates many short-lived members should call cancel
+// once it is done with each of them, or the group retains them until it is
+// stopped.
+func (g *Group) New() (ctx context.Context, cancel context.CancelFunc) {
+	return context.WithCancel(g.c)
+}
@@ -0,0 +1,61 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyGroup(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	g, stop := NotifyGroup(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	var members []context.Context
+	for i := 0; i < 3; i++ {
+		ctx, cancel := g.New()
+		defer cancel()
+		members = append(members, ctx)
+	}
+	single, cancelSingle := g.New()
+	cancelSingle()
+	if single.Err() == nil {
+		t.Errorf("expected cancel to cancel its member")
+	}
+	for i, ctx := range members {
+		if err := ctx.Err(); err != nil {
+			t.Errorf("member %d: Err() = %v after another member was canceled, want nil", i, err)
+		}
+	}
+	if len(n.signals) != 1 {
+		t.Errorf("registered %v, want the signals registered once for the group", n.signals)
+	}
+
+	n.send(syscall.SIGTERM)
+	for i, ctx := range members {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("member %d: timed out waiting for SIGTERM to cancel it", i)
+		}
+		if sig, ok := Signal(ctx); !ok || sig != syscall.SIGTERM {
+			t.Errorf("member %d: Signal() = %v, %v, want %v, true", i, sig, ok, syscall.SIGTERM)
+		}
+	}
+}
+
+func TestNotifyGroupStop(t *testing.T) {
+	g, stop := NotifyGroup(context.Background(), os.Interrupt)
+	ctx, cancel := g.New()
+	defer cancel()
+	stop()
+	if ctx.Err() == nil {
+		t.Errorf("expected stop to cancel the members of the group")
+	}
+}
@@ -0,0 +1,56 @@
+package sigctx
+
+import (
+	"context"
+	"time"
+)
+
+// A GRPCServer is a server that can be stopped gracefully or forcefully.
+// *grpc.Server from google.golang.org/grpc implements it, so that
+// WithGRPCServer does not need this package to depend on gRPC.
+type GRPCServer interface {
+	// GracefulStop stops accepting connections and RPCs and blocks until
+	// the pending RPCs are finished.
+	GracefulStop()
+
+	// Stop closes all connections and cancels the pending RPCs. It makes a
+	// GracefulStop in progress return.
+	Stop()
+}
+
+// WithGRPCServer stops srv once the context is done, calling
+// srv.GracefulStop in a background goroutine, and falling back to srv.Stop
+// if the pending RPCs do not finish within drain, as measured by a context
+// from ShutdownContext. ShutdownDone yields nil if srv stopped gracefully,
+// or the error of the drain context otherwise.
+func WithGRPCServer(srv GRPCServer, drain time.Duration) Option {
+	return func(o *options) {
+		done := make(chan error, 1)
+		o.shutdownDone = done
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			go func() {
+				defer close(done)
+				ctx, cancel := ShutdownContext(c, drain)
+				defer cancel()
+				done <- gracefulStop(ctx, srv)
+			}()
+		})
+	}
+}
+
+func gracefulStop(ctx context.Context, srv GRPCServer) error {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		srv.GracefulStop()
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		<-stopped
+		return ctx.Err()
+	}
+}
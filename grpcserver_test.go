@@ -0,0 +1,83 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeGRPCServer mimics a *grpc.Server with an RPC pending until release is
+// closed or Stop is called.
+type fakeGRPCServer struct {
+	release chan struct{}
+
+	mu       sync.Mutex
+	graceful bool
+	stopped  bool
+	stop     chan struct{}
+}
+
+func newFakeGRPCServer() *fakeGRPCServer {
+	return &fakeGRPCServer{release: make(chan struct{}), stop: make(chan struct{})}
+}
+
+func (s *fakeGRPCServer) GracefulStop() {
+	s.mu.Lock()
+	s.graceful = true
+	s.mu.Unlock()
+	select {
+	case <-s.release:
+	case <-s.stop:
+	}
+}
+
+func (s *fakeGRPCServer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+}
+
+func TestWithGRPCServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		release bool
+		want    error
+	}{
+		{"graceful", true, nil},
+		{"forced", false, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeGRPCServer()
+			if tt.release {
+				close(srv.release)
+			}
+			c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithGRPCServer(srv, 10*time.Millisecond))
+			defer stop()
+
+			Trigger(c, syscall.SIGTERM)
+			select {
+			case err := <-ShutdownDone(c):
+				if err != tt.want {
+					t.Errorf("ShutdownDone yielded %v, want %v", err, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for the server to stop")
+			}
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if !srv.graceful {
+				t.Errorf("expected GracefulStop to be called")
+			}
+			if srv.stopped == tt.release {
+				t.Errorf("Stop called = %v, want %v", srv.stopped, !tt.release)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrPanic is matched by the cause of a context canceled by GuardGoroutine
+// because a goroutine panicked.
+var ErrPanic = errors.New("sigctx: goroutine panicked")
+
+// A PanicError is the cause of a context canceled by GuardGoroutine. It
+// matches ErrPanic with errors.Is, as well as the panic value if it is an
+// error.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack of the panicking goroutine, as formatted by
+	// runtime/debug.Stack.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrPanic, e.Value)
+}
+
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrPanic, err}
+	}
+	return []error{ErrPanic}
+}
+
+// WithRepanic makes GuardGoroutine panic again with the recovered value
+// after canceling the context, instead of swallowing the panic.
+func WithRepanic() Option {
+	return func(o *options) {
+		o.repanic = true
+	}
+}
+
+// GuardGoroutine calls fn, typically as the body of a goroutine, and if fn
+// panics, cancels the signal context of ctx with a *PanicError so that the
+// other components of the program shut down cleanly. The panic is then
+// swallowed, unless the context was created with WithRepanic. If ctx has no
+// signal context, the panic is not recovered.
+func GuardGoroutine(ctx context.Context, fn func()) {
+	c, ok := fromContext(ctx)
+	if !ok {
+		fn()
+		return
+	}
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+		c.markCanceled()
+		c.cancel(&PanicError{Value: v, Stack: debug.Stack()})
+		if c.opts.repanic {
+			panic(v)
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,65 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+var errBoom = errors.New("boom")
+
+func TestGuardGoroutine(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		GuardGoroutine(c, func() { panic(errBoom) })
+	}()
+	<-done
+	<-c.Done()
+
+	cause := context.Cause(c)
+	if !errors.Is(cause, ErrPanic) || !errors.Is(cause, errBoom) {
+		t.Errorf("context.Cause(c) = %v, want %v wrapping %v", cause, ErrPanic, errBoom)
+	}
+	var pe *PanicError
+	if !errors.As(cause, &pe) || pe.Value != errBoom || !strings.Contains(cause.Error(), "boom") {
+		t.Errorf("context.Cause(c) = %#v, want a *PanicError for %v", cause, errBoom)
+	}
+}
+
+func TestGuardGoroutineRepanic(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithRepanic())
+	defer stop()
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("recovered %v, want boom", v)
+			}
+		}()
+		GuardGoroutine(c, func() { panic("boom") })
+	}()
+	if cause := context.Cause(c); !errors.Is(cause, ErrPanic) {
+		t.Errorf("context.Cause(c) = %v, want %v", cause, ErrPanic)
+	}
+}
+
+func TestGuardGoroutineNoPanic(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+
+	ran := false
+	GuardGoroutine(c, func() { ran = true })
+	if !ran {
+		t.Errorf("expected fn to run")
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("c.Err() = %v, want nil", err)
+	}
+}
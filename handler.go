@@ -0,0 +1,42 @@
+package sigctx
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ShutdownSignalKey is the key of the attribute added by the handler
+// returned by NewContextHandler.
+const ShutdownSignalKey = "shutdown_signal"
+
+// NewContextHandler returns a slog.Handler that passes records to next,
+// adding a ShutdownSignalKey attribute to those logged with a context that
+// was canceled by a signal, as reported by Signal. This tags every log
+// emitted during shutdown without passing the signal around explicitly.
+func NewContextHandler(next slog.Handler) slog.Handler {
+	return &contextHandler{next: next}
+}
+
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sig, ok := Signal(ctx); ok {
+		r = r.Clone()
+		r.AddAttrs(slog.String(ShutdownSignalKey, sig.String()))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{next: h.next.WithGroup(name)}
+}
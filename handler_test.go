@@ -0,0 +1,39 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestContextHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewContextHandler(slog.NewTextHandler(&buf, nil)))
+
+	c, stop := NotifyContext(context.Background(), syscall.SIGINT)
+	defer stop()
+
+	logger.InfoContext(c, "before")
+	if strings.Contains(buf.String(), ShutdownSignalKey) {
+		t.Errorf("log before the signal %q contains %s", buf.String(), ShutdownSignalKey)
+	}
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGINT")
+	}
+	buf.Reset()
+	logger.With("component", "test").InfoContext(c, "after")
+	if want := ShutdownSignalKey + "=interrupt"; !strings.Contains(buf.String(), want) {
+		t.Errorf("log after the signal %q does not contain %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,33 @@
+package sigctx
+
+import (
+	"os"
+	"time"
+)
+
+// WithHandoff coordinates an active/passive failover during a graceful
+// shutdown: when a signal would cancel the context, a value is first sent on
+// ready, telling a standby to take over, and the cancellation waits until
+// the standby acknowledges by sending on, or closing, ack. If the handoff
+// takes longer than timeout in total, the context is canceled anyway.
+// Calling stop during the handoff cancels the context right away.
+func WithHandoff(ready chan<- struct{}, ack <-chan struct{}, timeout time.Duration) Option {
+	return func(o *options) {
+		o.beforeCancel = append(o.beforeCancel, func(c *signalCtx, _ os.Signal) {
+			t := time.NewTimer(timeout)
+			defer t.Stop()
+			select {
+			case ready <- struct{}{}:
+			case <-t.C:
+				return
+			case <-c.Done():
+				return
+			}
+			select {
+			case <-ack:
+			case <-t.C:
+			case <-c.Done():
+			}
+		})
+	}
+}
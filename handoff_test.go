@@ -0,0 +1,56 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithHandoff(t *testing.T) {
+	ready := make(chan struct{})
+	ack := make(chan struct{})
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithHandoff(ready, ack, time.Minute))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-ready:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the standby to be told to take over")
+	}
+	select {
+	case <-c.Done():
+		t.Fatalf("context canceled before the standby acknowledged")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(ack)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after the acknowledgment")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
+
+func TestWithHandoffTimeout(t *testing.T) {
+	ready := make(chan struct{}, 1)
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithHandoff(ready, nil, 10*time.Millisecond))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done without an acknowledgment")
+	}
+	if len(ready) != 1 {
+		t.Errorf("expected the standby to be told to take over")
+	}
+}
@@ -0,0 +1,75 @@
+package sigctx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// WithHTTPServer shuts srv down once the context is done, calling
+// srv.Shutdown in a background goroutine with a context from ShutdownContext
+// that expires after drain. The result of Shutdown is available from
+// ShutdownDone. This suits programs that run srv.ListenAndServe themselves.
+func WithHTTPServer(srv *http.Server, drain time.Duration) Option {
+	return func(o *options) {
+		done := make(chan error, 1)
+		o.shutdownDone = done
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			go func() {
+				defer close(done)
+				ctx, cancel := ShutdownContext(c, drain)
+				defer cancel()
+				done <- srv.Shutdown(ctx)
+			}()
+		})
+	}
+}
+
+// ShutdownDone returns a channel that receives the result of the shutdown of
+// the server given to WithHTTPServer or WithGRPCServer, and is closed
+// afterwards. If ctx has no
+// signal context or no server, the returned channel is closed.
+func ShutdownDone(ctx context.Context) <-chan error {
+	if c, ok := fromContext(ctx); ok && c.opts.shutdownDone != nil {
+		return c.opts.shutdownDone
+	}
+	done := make(chan error)
+	close(done)
+	return done
+}
+
+// ServeWithSignals runs srv.Serve(ln) until one of the listed signals
+// arrives, then shuts srv down gracefully, with a context from
+// ShutdownContext that expires after DefaultDrainTimeout. It returns the
+// error of Serve, unless it is http.ErrServerClosed, or else the error of
+// Shutdown, so it returns nil once a signal shut the server down cleanly.
+// If Serve fails before any signal, ServeWithSignals returns its error right
+// away.
+func ServeWithSignals(srv *http.Server, ln net.Listener, signals ...os.Signal) error {
+	ctx, stop := NotifyContext(context.Background(), signals...)
+	defer stop()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-served:
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	sctx, cancel := ShutdownContext(ctx, DefaultDrainTimeout)
+	defer cancel()
+	err := srv.Shutdown(sctx)
+	if serr := <-served; serr != http.ErrServerClosed {
+		return serr
+	}
+	return err
+}
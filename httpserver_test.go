@@ -0,0 +1,93 @@
+package sigctx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithHTTPServer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	shutdown := make(chan struct{})
+	ts.Config.RegisterOnShutdown(func() { close(shutdown) })
+
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithHTTPServer(ts.Config, time.Second))
+	defer stop()
+
+	if _, err := http.Get(ts.URL); err != nil {
+		t.Fatalf("GET before signal: %v", err)
+	}
+	Trigger(c, syscall.SIGTERM)
+
+	select {
+	case err := <-ShutdownDone(c):
+		if err != nil {
+			t.Errorf("ShutdownDone yielded %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the server to shut down")
+	}
+	// Shutdown runs the RegisterOnShutdown functions in their own goroutines.
+	select {
+	case <-shutdown:
+	case <-time.After(time.Second):
+		t.Errorf("expected srv.Shutdown to be called")
+	}
+}
+
+func TestShutdownDoneWithoutServer(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+	if _, ok := <-ShutdownDone(c); ok {
+		t.Errorf("expected ShutdownDone without a server to be closed")
+	}
+}
+
+func TestServeWithSignals(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+	shutdown := make(chan struct{})
+	srv.RegisterOnShutdown(func() { close(shutdown) })
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeWithSignals(srv, ln, syscall.SIGTERM)
+	}()
+
+	if _, err := http.Get("http://" + ln.Addr().String()); err != nil {
+		t.Fatalf("GET before signal: %v", err)
+	}
+	for !n.send(syscall.SIGTERM) {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ServeWithSignals() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for ServeWithSignals to return")
+	}
+	select {
+	case <-shutdown:
+	case <-time.After(time.Second):
+		t.Errorf("expected srv.Shutdown to be called")
+	}
+	if _, err := http.Get("http://" + ln.Addr().String()); err == nil {
+		t.Errorf("GET after shutdown succeeded, want an error")
+	}
+}
@@ -0,0 +1,69 @@
+package sigctx
+
+import (
+	"context"
+	"time"
+)
+
+// countImpatient counts the signals that arrive after c was canceled, until
+// stop is called, and exits the process on the first one or escalates if so
+// configured.
+func (c *signalCtx) countImpatient() {
+	stopping := c.stoppingChan()
+	esc := c.opts.escalation
+	if _, ok := Signal(c); ok && esc != nil {
+		esc.last = time.Unix(0, c.canceledAt.Load())
+	}
+	for {
+		select {
+		case <-stopping:
+			return
+		case sig := <-c.ch:
+			if c.isStopped() {
+				return
+			}
+			if !c.opts.allowLate(sig) {
+				c.publish(Event{Type: Received, Signal: sig, Time: time.Now()})
+				continue
+			}
+			c.impatient.Add(1)
+			c.publish(Event{Type: Received, Signal: sig, Time: time.Now()})
+			if c.opts.forceExit {
+				osExit(c.opts.exitCode)
+			}
+			if esc != nil && esc.arrive(time.Now()) {
+				esc.fn()
+			}
+		}
+	}
+}
+
+// ImpatientCount returns how many of the listed signals the signal context of
+// ctx received after it was canceled and before its stop function was
+// called, for example because the user kept pressing Ctrl+C while the
+// program was slow to shut down. Signals held back by an overflow policy
+// are not counted. It returns 0 if ctx was not created by this package.
+func ImpatientCount(ctx context.Context) int {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return 0
+	}
+	return int(c.impatient.Load())
+}
+
+// SignalCount returns how many of the listed signals the signal context of
+// ctx received so far, from the one that canceled it, if any, to the last
+// one before its stop function was called, whether or not they canceled the
+// context. Signals held back by an overflow policy are not counted. It
+// returns 0 if ctx was not created by this package.
+//
+// Counting is free: the context keeps reading its signals until stop is
+// called anyway, so that they do not trigger their default behavior, and
+// only pays an atomic increment per signal.
+func SignalCount(ctx context.Context) int {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return 0
+	}
+	return int(c.delivered.Load())
+}
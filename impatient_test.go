@@ -0,0 +1,73 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestImpatientCount(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, n.option())
+	defer stop()
+	events := Events(c)
+
+	n.send(syscall.SIGINT)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGINT")
+	}
+	waitReceived(t, events)
+	if got := ImpatientCount(c); got != 0 {
+		t.Errorf("ImpatientCount(c) = %d after the first signal, want 0", got)
+	}
+	for i := 0; i < 2; i++ {
+		n.send(syscall.SIGINT)
+		waitReceived(t, events)
+	}
+	if got := ImpatientCount(c); got != 2 {
+		t.Errorf("ImpatientCount(c) = %d, want 2", got)
+	}
+
+	stop()
+	n.send(syscall.SIGINT)
+	if got := ImpatientCount(c); got != 2 {
+		t.Errorf("ImpatientCount(c) = %d after stop, want 2", got)
+	}
+	if got := ImpatientCount(context.Background()); got != 0 {
+		t.Errorf("ImpatientCount(context.Background()) = %d, want 0", got)
+	}
+}
+
+func TestSignalCount(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, n.option())
+	defer stop()
+	events := Events(c)
+
+	const sent = 5
+	for i := 0; i < sent; i++ {
+		for !n.send(syscall.SIGINT) {
+			time.Sleep(time.Millisecond)
+		}
+		waitReceived(t, events)
+	}
+	if got := SignalCount(c); got != sent {
+		t.Errorf("SignalCount(c) = %d, want %d", got, sent)
+	}
+	if got := ImpatientCount(c); got != sent-1 {
+		t.Errorf("ImpatientCount(c) = %d, want %d", got, sent-1)
+	}
+
+	stop()
+	n.send(syscall.SIGINT)
+	if got := SignalCount(c); got != sent {
+		t.Errorf("SignalCount(c) = %d after stop, want %d", got, sent)
+	}
+	if got := SignalCount(context.Background()); got != 0 {
+		t.Errorf("SignalCount(context.Background()) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,12 @@
+package sigctx
+
+// WithInterceptor wraps the action that cancels the context when a signal
+// arrives, so that tracing and metrics libraries can time or annotate it.
+// interceptor receives the function that cancels the context and returns the
+// function called in its place, which should call next. It is called once
+// per cancellation by a signal, on the goroutine delivering the signal.
+func WithInterceptor(interceptor func(next func()) func()) Option {
+	return func(o *options) {
+		o.interceptor = interceptor
+	}
+}
@@ -0,0 +1,33 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestWithInterceptor(t *testing.T) {
+	var before, after bool
+	var c context.Context
+	interceptor := func(next func()) func() {
+		return func() {
+			before = c.Err() == nil
+			next()
+			after = c.Err() != nil
+		}
+	}
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithInterceptor(interceptor))
+	defer stop()
+
+	Trigger(c, syscall.SIGTERM)
+	if !before {
+		t.Errorf("interceptor did not run before cancellation")
+	}
+	if !after {
+		t.Errorf("interceptor did not run after cancellation")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
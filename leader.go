@@ -0,0 +1,27 @@
+package sigctx
+
+import (
+	"os"
+	"time"
+)
+
+// WithLeaderCheck staggers the shutdown of a cluster: when a signal would
+// cancel the context, isLeader is called, and if it reports false the
+// cancellation is delayed by followerDelay, letting the leader go first. The
+// leader cancels immediately. Calling stop during the delay cancels the
+// context right away.
+func WithLeaderCheck(isLeader func() bool, followerDelay time.Duration) Option {
+	return func(o *options) {
+		o.beforeCancel = append(o.beforeCancel, func(c *signalCtx, _ os.Signal) {
+			if isLeader() {
+				return
+			}
+			t := time.NewTimer(followerDelay)
+			defer t.Stop()
+			select {
+			case <-t.C:
+			case <-c.Done():
+			}
+		})
+	}
+}
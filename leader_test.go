@@ -0,0 +1,40 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithLeaderCheck(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	tests := []struct {
+		name     string
+		leader   bool
+		min, max time.Duration
+	}{
+		{"leader", true, 0, delay},
+		{"follower", false, delay, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n fakeNotifier
+			c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(),
+				WithLeaderCheck(func() bool { return tt.leader }, delay))
+			defer stop()
+
+			start := time.Now()
+			n.send(syscall.SIGTERM)
+			select {
+			case <-c.Done():
+			case <-time.After(tt.max):
+				t.Fatalf("timed out waiting for context to be done after %v", tt.max)
+			}
+			if d := time.Since(start); d < tt.min {
+				t.Errorf("context was canceled after %v, want at least %v", d, tt.min)
+			}
+		})
+	}
+}
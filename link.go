@@ -0,0 +1,21 @@
+package sigctx
+
+import "context"
+
+// LinkDeadline makes the signal context of ctx also cancel when other is
+// done, for example when the deadline of the request that started some
+// shutdown work passes, even though other is not its parent. The cause of
+// the cancellation is that of other, such as context.DeadlineExceeded. It
+// returns ErrNotSignalContext if ctx has no signal context.
+func LinkDeadline(ctx context.Context, other context.Context) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	unlink := context.AfterFunc(other, func() {
+		c.markCanceled()
+		c.cancel(context.Cause(other))
+	})
+	context.AfterFunc(c, func() { unlink() })
+	return nil
+}
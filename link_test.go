@@ -0,0 +1,44 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLinkDeadline(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+
+	other, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := LinkDeadline(c, other); err != nil {
+		t.Fatalf("LinkDeadline = %v", err)
+	}
+
+	select {
+	case <-c.Done():
+		if got := context.Cause(c); got != context.DeadlineExceeded {
+			t.Errorf("context.Cause(c) = %v, want %v", got, context.DeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after the linked deadline")
+	}
+	if err := LinkDeadline(context.Background(), other); err != ErrNotSignalContext {
+		t.Errorf("LinkDeadline(context.Background()) = %v, want %v", err, ErrNotSignalContext)
+	}
+}
+
+func TestLinkDeadlineStop(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	other, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	LinkDeadline(c, other)
+
+	stop()
+	cancel()
+	if got := context.Cause(c); got != context.Canceled {
+		t.Errorf("context.Cause(c) = %v, want %v", got, context.Canceled)
+	}
+}
@@ -0,0 +1,78 @@
+package sigctx
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// listenerInfo describes the goroutine watching the signals of a context.
+// Its fields are set once, before ready is done.
+type listenerInfo struct {
+	ready   sync.WaitGroup
+	started time.Time
+	goid    uint64 // 0 if unknown
+}
+
+// WithListenerID makes the goroutine watching the signals of the context
+// determine its identifier as it starts, for ListenerInfo to report it.
+// Determining the identifier costs a few microseconds per context.
+func WithListenerID() Option {
+	return func(o *options) {
+		o.listenerID = true
+	}
+}
+
+// ListenerInfo returns when the goroutine watching the signals of the signal
+// context of ctx started, and a best-effort identifier of that goroutine, as
+// it appears in stack dumps such as those of SIGQUIT or runtime.Stack, to
+// help find it in the dump of a shutdown that hangs. The identifier is empty
+// unless the context was created with WithListenerID, or if it could not be
+// determined. ListenerInfo returns a zero time and an empty identifier if
+// ctx has no signal context, or if the goroutine was never started because
+// the parent was already done.
+func ListenerInfo(ctx context.Context) (started time.Time, goid string) {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return time.Time{}, ""
+	}
+	c.listener.ready.Wait()
+	if c.listener.goid != 0 {
+		goid = strconv.FormatUint(c.listener.goid, 10)
+	}
+	return c.listener.started, goid
+}
+
+// stackHeaders holds buffers for the header of a stack trace, to keep
+// goroutineID from allocating.
+var stackHeaders = sync.Pool{
+	New: func() any { return new([64]byte) },
+}
+
+// goroutineID returns the identifier of the calling goroutine, parsed from
+// the header of its stack trace, "goroutine 123 [running]:", or 0 if the
+// header does not have the expected format.
+func goroutineID() uint64 {
+	buf := stackHeaders.Get().(*[64]byte)
+	defer stackHeaders.Put(buf)
+	b := buf[:runtime.Stack(buf[:], false)]
+	b, ok := bytes.CutPrefix(b, []byte("goroutine "))
+	if !ok {
+		return 0
+	}
+	var id uint64
+	for i, r := range b {
+		switch {
+		case r >= '0' && r <= '9':
+			id = id*10 + uint64(r-'0')
+		case r == ' ' && i > 0:
+			return id
+		default:
+			return 0
+		}
+	}
+	return 0
+}
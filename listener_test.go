@@ -0,0 +1,46 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestListenerInfo(t *testing.T) {
+	start := time.Now()
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithListenerID())
+	defer stop()
+
+	started, goid := ListenerInfo(c)
+	if started.IsZero() || started.Before(start) {
+		t.Errorf("started = %v, want a time after %v", started, start)
+	}
+	if goid == "" || goid == strconv.FormatUint(goroutineID(), 10) {
+		t.Errorf("goid = %q, want the identifier of another goroutine", goid)
+	}
+	if !strings.Contains(string(allStacks()), "goroutine "+goid+" [") {
+		t.Errorf("goroutine %s not found in the stack dump", goid)
+	}
+}
+
+func TestListenerInfoWithoutID(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+	if started, goid := ListenerInfo(c); started.IsZero() || goid != "" {
+		t.Errorf("ListenerInfo(c) = %v, %q, want a start time and no identifier", started, goid)
+	}
+}
+
+func TestListenerInfoParentDone(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM})
+	defer stop()
+	if started, goid := ListenerInfo(c); !started.IsZero() || goid != "" {
+		t.Errorf("ListenerInfo(c) = %v, %q, want zero values", started, goid)
+	}
+}
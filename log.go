@@ -0,0 +1,62 @@
+package sigctx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// WithLogger makes the context log its lifecycle events, as delivered by
+// Events, to logger. A nil logger, the default, disables logging.
+//
+// A signal is logged at slog.LevelInfo, with its name and the process ID
+// as the "signal" and "pid" attributes, by the goroutine watching the
+// signals as soon as it receives it, so before the signal cancels the
+// context. The cancellation itself and the call to stop are then logged at
+// slog.LevelDebug. WithLogLevels changes these levels.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithLogLevels sets the levels at which WithLogger logs the Received,
+// Canceled and Stopped events, which default to slog.LevelInfo,
+// slog.LevelDebug and slog.LevelDebug.
+func WithLogLevels(received, canceled, stopped slog.Level) Option {
+	return func(o *options) {
+		o.logLevels[Received] = received
+		o.logLevels[Canceled] = canceled
+		o.logLevels[Stopped] = stopped
+	}
+}
+
+var eventMessages = map[EventType]string{
+	Received: "sigctx: signal received",
+	Canceled: "sigctx: context canceled",
+	Stopped:  "sigctx: context stopped",
+}
+
+func (c *signalCtx) logEvent(e Event) {
+	logger := c.opts.logger
+	if logger == nil {
+		return
+	}
+	var attrs []slog.Attr
+	if e.Signal != nil {
+		attrs = append(attrs, slog.String("signal", e.Signal.String()), slog.Int("pid", os.Getpid()))
+	}
+	if c.opts.callerTag != "" {
+		attrs = append(attrs, slog.String("caller", c.opts.callerTag))
+	}
+	logger.LogAttrs(context.Background(), c.opts.logLevels[e.Type], eventMessages[e.Type], attrs...)
+}
+
+// warnLogger returns the logger for warnings and errors, which are logged
+// to the default logger unless a logger was set with WithLogger.
+func (o *options) warnLogger() *slog.Logger {
+	if o.logger != nil {
+		return o.logger
+	}
+	return slog.Default()
+}
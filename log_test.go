@@ -0,0 +1,103 @@
+package sigctx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+// recordingHandler is a slog.Handler that keeps the records it handles.
+type recordingHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordingHandler) WithGroup(string) slog.Handler { return h }
+
+func (h *recordingHandler) levels() map[string]slog.Level {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	levels := make(map[string]slog.Level)
+	for _, r := range h.records {
+		levels[r.Message] = r.Level
+	}
+	return levels
+}
+
+func TestWithLogLevels(t *testing.T) {
+	var h recordingHandler
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM},
+		WithLogger(slog.New(&h)),
+		WithLogLevels(slog.LevelInfo, slog.LevelWarn, slog.LevelDebug))
+	Trigger(c, syscall.SIGTERM)
+	stop()
+
+	want := map[string]slog.Level{
+		"sigctx: signal received":  slog.LevelInfo,
+		"sigctx: context canceled": slog.LevelWarn,
+		"sigctx: context stopped":  slog.LevelDebug,
+	}
+	if got := h.levels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("logged %v, want %v", got, want)
+	}
+}
+
+func TestWithLoggerDefaultLevels(t *testing.T) {
+	var h recordingHandler
+	_, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithLogger(slog.New(&h)))
+	stop()
+
+	want := map[string]slog.Level{"sigctx: context stopped": slog.LevelDebug}
+	if got := h.levels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("logged %v, want %v", got, want)
+	}
+}
+
+func TestWithLoggerSignal(t *testing.T) {
+	var h recordingHandler
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithLogger(slog.New(&h)))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	stop()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.records) == 0 || h.records[0].Message != "sigctx: signal received" {
+		t.Fatalf("expected the signal to be logged first, got %d records", len(h.records))
+	}
+	r := h.records[0]
+	if r.Level != slog.LevelInfo {
+		t.Errorf("signal logged at %v, want %v", r.Level, slog.LevelInfo)
+	}
+	attrs := make(map[string]string)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.String()
+		return true
+	})
+	want := map[string]string{
+		"signal": syscall.SIGTERM.String(),
+		"pid":    strconv.Itoa(os.Getpid()),
+	}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("signal logged with %v, want %v", attrs, want)
+	}
+}
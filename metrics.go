@@ -0,0 +1,27 @@
+package sigctx
+
+import "os"
+
+// Metrics receives the events of a context created with WithMetrics, so that
+// they can be counted by a metrics library that this package need not
+// import. Its methods are called synchronously, usually on the goroutine
+// watching the signals, and should return quickly: SignalReceived is called
+// before the signal cancels the context, which waits for it.
+type Metrics interface {
+	// SignalReceived is called for every listed signal that arrives, as
+	// for the Received events of Events.
+	SignalReceived(sig os.Signal)
+
+	// ContextCancelled is called once the context was canceled by a signal,
+	// its parent or an option, as for the Canceled event of Events. It is
+	// not called when the context is canceled by its stop function.
+	ContextCancelled()
+}
+
+// WithMetrics makes the context report its signals and its cancellation to
+// m.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
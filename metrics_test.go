@@ -0,0 +1,71 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeMetrics records the calls made to it, in order.
+type fakeMetrics struct {
+	mu    sync.Mutex
+	calls []string
+	c     context.Context
+}
+
+func (m *fakeMetrics) SignalReceived(sig os.Signal) {
+	state := "live"
+	if m.c.Err() != nil {
+		state = "done"
+	}
+	m.record("received " + sig.String() + " " + state)
+}
+
+func (m *fakeMetrics) ContextCancelled() { m.record("cancelled") }
+
+func (m *fakeMetrics) record(call string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+}
+
+func (m *fakeMetrics) recorded() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+func TestWithMetrics(t *testing.T) {
+	var n fakeNotifier
+	m := &fakeMetrics{}
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithMetrics(m))
+	defer stop()
+	m.c = c
+	events := Events(c)
+
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	n.send(syscall.SIGTERM)
+	waitReceived(t, events)
+	waitReceived(t, events)
+	stop()
+
+	want := []string{"received terminated live", "cancelled", "received terminated done"}
+	if got := m.recorded(); !reflect.DeepEqual(got, want) {
+		t.Errorf("metrics got %q, want %q", got, want)
+	}
+}
+
+func TestWithMetricsStop(t *testing.T) {
+	m := &fakeMetrics{}
+	_, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithMetrics(m))
+	stop()
+	time.Sleep(10 * time.Millisecond)
+	if got := m.recorded(); len(got) != 0 {
+		t.Errorf("metrics got %q after stop, want no calls", got)
+	}
+}
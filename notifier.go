@@ -0,0 +1,114 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// A Notifier keeps a signal registration alive across a sequence of
+// contexts. Unlike the stop function returned by NotifyContext, the stop
+// function of a Notifier context does not unregister the signals; it cancels
+// the context and arms a new one, so that no signal is restored to its
+// default behavior between two iterations of a supervisor loop:
+//
+//	n := sigctx.WithRearmOnStop(ctx, syscall.SIGHUP)
+//	defer n.Close()
+//	for {
+//		ctx, stop := n.Context()
+//		run(ctx)
+//		stop()
+//	}
+type Notifier struct {
+	parent context.Context
+	ch     chan os.Signal
+	done   chan struct{}
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	closed bool
+}
+
+// WithRearmOnStop registers the given signals and returns a Notifier whose
+// contexts are derived from parent and are canceled when one of the signals
+// arrives. The registration lasts until Close is called.
+func WithRearmOnStop(parent context.Context, signals ...os.Signal) *Notifier {
+	n := &Notifier{
+		parent: parent,
+		ch:     make(chan os.Signal, 1),
+		done:   make(chan struct{}),
+	}
+	n.arm()
+	signal.Notify(n.ch, signals...)
+	n.wg.Add(1)
+	go n.watch()
+	return n
+}
+
+// arm replaces the current context with a new one. n.mu must be held or n
+// must not be shared yet.
+func (n *Notifier) arm() {
+	n.ctx, n.cancel = context.WithCancel(n.parent)
+}
+
+func (n *Notifier) watch() {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.ch:
+			n.mu.Lock()
+			n.cancel()
+			n.mu.Unlock()
+		case <-n.done:
+			return
+		}
+	}
+}
+
+// Context returns the current context and a function that cancels it and
+// arms the next one. Calling Context again before stop returns the same
+// context. A signal that arrives after the current context was canceled,
+// but before stop is called, is dropped.
+//
+// After Close, Context returns a context that is already canceled.
+func (n *Notifier) Context() (ctx context.Context, stop context.CancelFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ctx = n.ctx
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() { n.rearm(ctx) })
+	}
+}
+
+// rearm cancels ctx and, if it is still the current context, arms a new one.
+func (n *Notifier) rearm(ctx context.Context) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ctx != ctx {
+		return
+	}
+	n.cancel()
+	if !n.closed {
+		n.arm()
+	}
+}
+
+// Close unregisters the signals and cancels the current context.
+func (n *Notifier) Close() {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return
+	}
+	n.closed = true
+	n.cancel()
+	n.mu.Unlock()
+
+	signal.Stop(n.ch)
+	close(n.done)
+	n.wg.Wait()
+}
@@ -0,0 +1,53 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifierRearm(t *testing.T) {
+	n := WithRearmOnStop(context.Background(), syscall.SIGUSR1)
+	defer n.Close()
+
+	for i := 0; i < 3; i++ {
+		c, stop := n.Context()
+		if err := c.Err(); err != nil {
+			t.Fatalf("cycle %d: c.Err() = %v before signal, want nil", i, err)
+		}
+		syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+		select {
+		case <-c.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("cycle %d: timed out waiting for context to be done after SIGUSR1", i)
+		}
+		stop()
+		if next, _ := n.Context(); next == c {
+			t.Fatalf("cycle %d: stop did not arm a new context", i)
+		}
+	}
+}
+
+func TestNotifierClose(t *testing.T) {
+	n := WithRearmOnStop(context.Background(), syscall.SIGHUP)
+	c, stop := n.Context()
+	defer stop()
+
+	if signal.Ignored(syscall.SIGHUP) {
+		t.Errorf("expected SIGHUP to not be ignored while the notifier is open")
+	}
+	n.Close()
+	select {
+	case <-c.Done():
+	default:
+		t.Errorf("expected Close to cancel the current context")
+	}
+	if next, _ := n.Context(); next.Err() == nil {
+		t.Errorf("expected Context after Close to return a canceled context")
+	}
+}
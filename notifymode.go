@@ -0,0 +1,157 @@
+package sigctx
+
+import (
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+)
+
+// A NotifyMode tells how a context registers for signals.
+type NotifyMode int
+
+const (
+	// ProcessWide registers the channel of each context with signal.Notify,
+	// and its stop function calls signal.Stop, which restores the default
+	// behavior of the signals no other channel is registered for.
+	ProcessWide NotifyMode = iota
+
+	// Isolated subscribes each context to a dispatcher shared by the
+	// package, which registers with signal.Notify on behalf of all of
+	// them. Stopping a context then only unsubscribes it: the signals it
+	// listed that another subscribed context still wants stay caught,
+	// without ever regaining their default behavior, so that one component
+	// stopping cannot make a signal kill the process while another still
+	// relies on catching it. Only the signals that no subscribed context
+	// wants any longer are unregistered. Signals remain process-wide; a
+	// signal delivered to the process reaches every subscribed context
+	// listing it.
+	Isolated
+)
+
+// WithNotifyMode sets how the context registers for signals. The default is
+// ProcessWide. A notifier set with WithNotifier takes precedence over the
+// mode, whatever the order of the options: the context then registers with
+// the notifier alone.
+func WithNotifyMode(mode NotifyMode) Option {
+	return func(o *options) {
+		if o.customNotifier {
+			return
+		}
+		switch mode {
+		case ProcessWide:
+			o.notify = notifyFunc
+			o.stopNotify = stopFunc
+		case Isolated:
+			o.notify = isolated.subscribe
+			o.stopNotify = isolated.unsubscribe
+		}
+	}
+}
+
+// isolated is the dispatcher of the contexts in Isolated mode.
+var isolated = &dispatcher{notify: signal.Notify, stop: signal.Stop}
+
+// A dispatcher relays the signals it registered for with notify to its
+// subscribers.
+type dispatcher struct {
+	notify func(c chan<- os.Signal, sig ...os.Signal)
+	stop   func(c chan<- os.Signal)
+
+	mu   sync.Mutex
+	ch   chan os.Signal
+	subs map[chan<- os.Signal][]os.Signal
+
+	// registered are the signals ch is registered for, unless all is set.
+	registered []os.Signal
+	all        bool
+}
+
+func (d *dispatcher) subscribe(c chan<- os.Signal, sig ...os.Signal) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ch == nil {
+		d.ch = make(chan os.Signal, eventBuffer)
+		d.subs = make(map[chan<- os.Signal][]os.Signal)
+		go d.relay(d.ch)
+	}
+	d.subs[c] = append([]os.Signal(nil), sig...)
+	d.notify(d.ch, sig...)
+	d.all = d.all || len(sig) == 0
+	d.registered = appendMissing(d.registered, sig...)
+}
+
+// unsubscribe stops relaying signals to c. Once it returns, no more signals
+// are sent to c, and the signals that no subscriber wants any longer are
+// unregistered.
+func (d *dispatcher) unsubscribe(c chan<- os.Signal) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.subs[c]; !ok {
+		return
+	}
+	delete(d.subs, c)
+	var wanted []os.Signal
+	for _, sigs := range d.subs {
+		if len(sigs) == 0 {
+			// A subscriber still wants all signals.
+			return
+		}
+		wanted = appendMissing(wanted, sigs...)
+	}
+	if !d.all && len(wanted) == len(d.registered) {
+		return
+	}
+	// The signal package cannot unregister some of the signals of a
+	// channel, so register the wanted ones for a new channel before
+	// unregistering the old one, so that they stay caught throughout.
+	old := d.ch
+	d.ch, d.registered, d.all = nil, wanted, false
+	if len(wanted) > 0 {
+		d.ch = make(chan os.Signal, eventBuffer)
+		d.notify(d.ch, wanted...)
+		go d.relay(d.ch)
+	}
+	d.stop(old)
+	close(old)
+}
+
+// appendMissing appends the signals of sig that are not in sigs yet.
+func appendMissing(sigs []os.Signal, sig ...os.Signal) []os.Signal {
+	for _, s := range sig {
+		if !slices.Contains(sigs, s) {
+			sigs = append(sigs, s)
+		}
+	}
+	return sigs
+}
+
+func (d *dispatcher) relay(ch <-chan os.Signal) {
+	for sig := range ch {
+		d.mu.Lock()
+		for c, sigs := range d.subs {
+			if !wants(sigs, sig) {
+				continue
+			}
+			select {
+			case c <- sig:
+			default:
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// wants reports whether a subscriber for sigs, which means all signals if
+// empty, wants sig.
+func wants(sigs []os.Signal, sig os.Signal) bool {
+	if len(sigs) == 0 {
+		return true
+	}
+	for _, s := range sigs {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithNotifyModeIsolated(t *testing.T) {
+	var n fakeNotifier
+	old := isolated
+	isolated = &dispatcher{notify: n.notify, stop: n.stop}
+	defer func() { isolated = old }()
+
+	c1, stop1 := New(context.Background(), []os.Signal{syscall.SIGINT}, WithNotifyMode(Isolated))
+	defer stop1()
+	c2, stop2 := New(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, WithNotifyMode(Isolated))
+	defer stop2()
+
+	stop1()
+	if n.stopped {
+		t.Errorf("stop unregistered from the signal package in Isolated mode")
+	}
+	n.mu.Lock()
+	registered := n.signals
+	n.mu.Unlock()
+	if !wants(registered, syscall.SIGINT) {
+		t.Errorf("registered %v, want SIGINT kept after stop", registered)
+	}
+
+	n.send(syscall.SIGINT)
+	select {
+	case <-c2.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the remaining context to be done after SIGINT")
+	}
+	if sig, ok := Signal(c1); ok {
+		t.Errorf("Signal(c1) = %v, true after stop", sig)
+	}
+	if sig, ok := Signal(c2); !ok || sig != syscall.SIGINT {
+		t.Errorf("Signal(c2) = %v, %v, want %v, true", sig, ok, syscall.SIGINT)
+	}
+}
+
+// signalTable registers channels for signals like the signal package.
+type signalTable struct {
+	mu    sync.Mutex
+	chans map[chan<- os.Signal][]os.Signal
+}
+
+func (s *signalTable) notify(c chan<- os.Signal, sig ...os.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.chans == nil {
+		s.chans = make(map[chan<- os.Signal][]os.Signal)
+	}
+	s.chans[c] = append(s.chans[c], sig...)
+}
+
+func (s *signalTable) stop(c chan<- os.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.chans, c)
+}
+
+// caught reports whether a channel is registered for sig.
+func (s *signalTable) caught(sig os.Signal) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sigs := range s.chans {
+		if wants(sigs, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *signalTable) send(sig os.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c, sigs := range s.chans {
+		if wants(sigs, sig) {
+			select {
+			case c <- sig:
+			default:
+			}
+		}
+	}
+}
+
+func TestWithNotifyModeIsolatedUnregisters(t *testing.T) {
+	var table signalTable
+	old := isolated
+	isolated = &dispatcher{notify: table.notify, stop: table.stop}
+	defer func() { isolated = old }()
+
+	_, stop1 := New(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, WithNotifyMode(Isolated))
+	defer stop1()
+	c2, stop2 := New(context.Background(), []os.Signal{syscall.SIGINT}, WithNotifyMode(Isolated))
+	defer stop2()
+
+	stop1()
+	if table.caught(syscall.SIGTERM) {
+		t.Errorf("SIGTERM is still caught after its only subscriber stopped")
+	}
+	if !table.caught(syscall.SIGINT) {
+		t.Errorf("SIGINT is no longer caught while a subscriber wants it")
+	}
+	table.send(syscall.SIGINT)
+	select {
+	case <-c2.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the remaining context to be done after SIGINT")
+	}
+
+	stop2()
+	if table.caught(syscall.SIGINT) {
+		t.Errorf("SIGINT is still caught after every subscriber stopped")
+	}
+}
+
+func TestWantsAll(t *testing.T) {
+	if !wants(nil, syscall.SIGTERM) {
+		t.Errorf("expected a subscriber without signals to want every signal")
+	}
+	if wants([]os.Signal{syscall.SIGINT}, syscall.SIGTERM) {
+		t.Errorf("expected a subscriber for SIGINT not to want SIGTERM")
+	}
+}
+
+func TestWithNotifyModeNotifier(t *testing.T) {
+	for _, mode := range []NotifyMode{ProcessWide, Isolated} {
+		var n fakeNotifier
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithNotifyMode(mode))
+		if !n.send(syscall.SIGTERM) {
+			t.Errorf("mode %d: the context did not register with the notifier", mode)
+		}
+		<-c.Done()
+		stop()
+		if !n.stopped {
+			t.Errorf("mode %d: stop did not unregister from the notifier", mode)
+		}
+	}
+}
+
+func TestWithNotifyModeProcessWide(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	_, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithNotifyMode(Isolated), WithNotifyMode(ProcessWide))
+	defer stop()
+	if !wants(n.signals, syscall.SIGTERM) {
+		t.Errorf("registered %v, want SIGTERM registered with notifyFunc", n.signals)
+	}
+}
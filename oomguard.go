@@ -0,0 +1,48 @@
+package sigctx
+
+import (
+	"errors"
+	"runtime"
+	"time"
+)
+
+// ErrMemoryPressure is the cause of a context canceled because the memory
+// used by the process exceeded the threshold given to WithOOMGuard.
+var ErrMemoryPressure = errors.New("sigctx: memory usage exceeded threshold")
+
+// memCheckInterval is how often WithOOMGuard reads the memory usage.
+var memCheckInterval = time.Second
+
+// readMemUsage returns the memory the process holds from the operating
+// system, in bytes. It is replaced in tests.
+var readMemUsage = func() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.Sys - m.HeapReleased
+}
+
+// WithOOMGuard makes the context also cancel, with cause ErrMemoryPressure,
+// when the memory the Go runtime holds from the operating system exceeds
+// threshold bytes, letting a batch job stop gracefully before the kernel
+// kills it. The usage is checked every second, which briefly stops the world.
+// Memory allocated outside the Go runtime, for example by cgo, is not
+// counted.
+func WithOOMGuard(threshold uint64) Option {
+	return func(o *options) {
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			t := time.NewTicker(memCheckInterval)
+			defer t.Stop()
+			for {
+				select {
+				case <-c.Done():
+					return
+				case <-t.C:
+				}
+				if readMemUsage() > threshold {
+					c.cancel(ErrMemoryPressure)
+					return
+				}
+			}
+		})
+	}
+}
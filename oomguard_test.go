@@ -0,0 +1,38 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithOOMGuard(t *testing.T) {
+	oldInterval, oldRead := memCheckInterval, readMemUsage
+	defer func() { memCheckInterval, readMemUsage = oldInterval, oldRead }()
+	memCheckInterval = time.Millisecond
+	var usage atomic.Uint64
+	usage.Store(100)
+	readMemUsage = usage.Load
+
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithOOMGuard(1000))
+	defer stop()
+
+	select {
+	case <-c.Done():
+		t.Fatalf("context canceled below the threshold: %v", context.Cause(c))
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	usage.Store(1001)
+	select {
+	case <-c.Done():
+		if got := context.Cause(c); got != ErrMemoryPressure {
+			t.Errorf("context.Cause(c) = %v, want %v", got, ErrMemoryPressure)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for context to be done above the threshold")
+	}
+	stop()
+}
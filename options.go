@@ -0,0 +1,225 @@
+package sigctx
+
+import (
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// An Option customizes a context returned by New.
+type Option func(*options)
+
+type options struct {
+	notify     func(c chan<- os.Signal, sig ...os.Signal)
+	stopNotify func(c chan<- os.Signal)
+
+	// reset is set by WithReset.
+	reset bool
+
+	// onStop are the functions given to WithOnStop.
+	onStop []func()
+
+	// customNotifier is set when notify and stopNotify were replaced, so
+	// that the signal channel may still be used after stopNotify returns.
+	customNotifier bool
+
+	// severity overrides defaultSeverity. It is nil until an option sets the
+	// severity of a signal.
+	severity map[os.Signal]SeverityLevel
+
+	// setups run before NotifyContext returns, and watchers run in their
+	// own goroutines for as long as the context is not done. Both only run
+	// if the parent is not already done.
+	setups   []func(*signalCtx)
+	watchers []func(*signalCtx)
+
+	// cancelAtCreation, set by ImportState for a context that was done,
+	// cancels the context as it is created, in place of diverting its
+	// signals, unless the parent is already done.
+	cancelAtCreation func(*signalCtx)
+
+	stopTrace bool
+
+	listenerID bool
+
+	propagatedKeys []any
+
+	maxLifetime time.Duration
+
+	// processGroup is set by WithProcessGroup. It has no effect yet.
+	processGroup bool
+
+	reasonPriority []Reason
+
+	durationSink func(time.Duration)
+
+	shutdownDone chan error
+
+	flushResult *flushResult
+
+	forceExit bool
+	exitCode  int
+
+	escalation *escalation
+
+	repanic bool
+
+	grace *grace
+
+	// signalCause is set by withSignalCause.
+	signalCause bool
+
+	countdown chan time.Duration
+
+	abortOnPhaseError bool
+
+	// preStopAddr is the address of the endpoint of WithPreStopEndpoint.
+	preStopAddr net.Addr
+
+	drainTimeout time.Duration
+
+	auditSink func(AuditEntry)
+
+	metrics Metrics
+
+	overflow *OverflowPolicy
+
+	bufferSize int
+
+	logger    *slog.Logger
+	logLevels [Stopped + 1]slog.Level
+
+	interceptor func(next func()) func()
+
+	// callerTag is the call site recorded by WithCallerTag.
+	callerTag string
+
+	// terminal is the set of signals that request the exit of the program,
+	// or nil if every listed signal does.
+	terminal map[os.Signal]bool
+
+	// beforeCancel hooks run, in order, on the goroutine watching the
+	// signals after a signal was allowed to cancel the context and before it
+	// does. A hook may delay cancellation, but should return early if the
+	// context is done in the meantime.
+	beforeCancel []func(c *signalCtx, sig os.Signal)
+
+	// all is set by NotifyContextAll, whose context listens to every
+	// signal.
+	all bool
+
+	// gates decide whether a received signal cancels the context. They are
+	// only called from the goroutine watching the signals.
+	gates []func(sig os.Signal, now time.Time) bool
+
+	// lateGates decide whether a signal that arrives once the context is
+	// canceled counts as impatient; a gate that refuses a signal handles it
+	// itself. They are only called from the goroutine watching the signals.
+	lateGates []func(sig os.Signal) bool
+}
+
+// notifyFunc and stopFunc register and unregister signal channels, unless
+// replaced with WithNotifier. They are replaced in tests.
+var (
+	notifyFunc = signal.Notify
+	stopFunc   = signal.Stop
+)
+
+func newOptions(opts []Option) options {
+	o := options{
+		notify:     notifyFunc,
+		stopNotify: stopFunc,
+
+		reasonPriority: defaultReasonPriority,
+		drainTimeout:   DefaultDrainTimeout,
+		bufferSize:     1,
+	}
+	for t := range o.logLevels {
+		o.logLevels[t] = slog.LevelDebug
+	}
+	o.logLevels[Received] = slog.LevelInfo
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// allow reports whether sig, received now, cancels the context. Every gate
+// sees every signal, even when an earlier one already refused it.
+func (o *options) allow(sig os.Signal) bool {
+	now := time.Now()
+	ok := true
+	for _, gate := range o.gates {
+		if !gate(sig, now) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// allowLate reports whether sig, received once the context is canceled,
+// counts as impatient. Every late gate sees every signal, as with allow.
+func (o *options) allowLate(sig os.Signal) bool {
+	ok := true
+	for _, gate := range o.lateGates {
+		if !gate(sig) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// WithNotifier replaces signal.Notify and signal.Stop, which the context
+// uses to register and unregister its channel, with notify and stop. It lets
+// tests deliver signals, such as a TestSignal, by sending them on the channel
+// given to notify instead of sending real signals to the process.
+//
+// The channel has a buffer of one signal, unless set with WithBufferSize;
+// like the signal package, notify should not block when the channel is full.
+func WithNotifier(notify func(c chan<- os.Signal, sig ...os.Signal), stop func(c chan<- os.Signal)) Option {
+	return func(o *options) {
+		o.notify = notify
+		o.stopNotify = stop
+		o.customNotifier = true
+	}
+}
+
+// WithBufferSize sets the capacity of the channel on which the context
+// receives its signals, which defaults to one. The signal package does not
+// block when the channel is full and drops the signal instead, so a burst
+// of distinct signals that arrives faster than the context handles them may
+// lose all but the first, which is enough to cancel the context, but not to
+// report the others through Events or ImpatientCount. A larger buffer keeps
+// them. Identical signals that arrive at the same time may still be
+// coalesced by the operating system and the runtime, whatever the buffer
+// size. A size smaller than the one requested by another option, such as
+// WithReplayBuffer, has no effect.
+func WithBufferSize(n int) Option {
+	return func(o *options) {
+		if n > o.bufferSize {
+			o.bufferSize = n
+		}
+	}
+}
+
+// WithDurationSink makes the first call to stop report to sink the time
+// elapsed between the cancellation of the context and the end of stop, which
+// measures how long a program takes to shut down.
+func WithDurationSink(sink func(d time.Duration)) Option {
+	return func(o *options) {
+		o.durationSink = sink
+	}
+}
+
+// WithMaxLifetime stops watching for signals d after the context was
+// created, as a safety net for a forgotten call to stop. Once d has elapsed,
+// the signals are unregistered as if stop had been called, but the context is
+// not canceled: it keeps behaving like a context returned by
+// context.WithCancel, and stop should still be called to release it.
+func WithMaxLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.maxLifetime = d
+	}
+}
@@ -0,0 +1,83 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithMaxLifetime(t *testing.T) {
+	// Keep SIGUSR1 from terminating the test once the context unregisters it.
+	other := make(chan os.Signal, 1)
+	signal.Notify(other, syscall.SIGUSR1)
+	defer signal.Stop(other)
+
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGUSR1}, WithMaxLifetime(10*time.Millisecond))
+	defer stop()
+
+	torndown := make(chan struct{})
+	go func() {
+		c.(*signalCtx).wg.Wait()
+		close(torndown)
+	}()
+	select {
+	case <-torndown:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the listener to be torn down")
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("c.Err() = %v after max lifetime, want nil", err)
+	}
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for SIGUSR1")
+	}
+	select {
+	case <-c.Done():
+		t.Errorf("expected a signal after max lifetime not to cancel the context")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stop()
+	if got := c.Err(); got != context.Canceled {
+		t.Errorf("c.Err() = %v after stop, want %v", got, context.Canceled)
+	}
+}
+
+func TestWithBufferSize(t *testing.T) {
+	var n fakeNotifier
+	sigs := []os.Signal{syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM}
+	c, stop := New(context.Background(), sigs, n.option(), WithBufferSize(len(sigs)))
+	defer stop()
+	events := Events(c)
+
+	for _, sig := range sigs {
+		if !n.send(sig) {
+			t.Errorf("%v dropped, want it buffered", sig)
+		}
+	}
+	var got []os.Signal
+	for len(got) < len(sigs) {
+		select {
+		case e := <-events:
+			if e.Type == Received {
+				got = append(got, e.Signal)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("received %v, want %v", got, sigs)
+		}
+	}
+	if !reflect.DeepEqual(got, sigs) {
+		t.Errorf("received %v, want %v", got, sigs)
+	}
+}
@@ -0,0 +1,93 @@
+package sigctx
+
+import "os"
+
+// An OverflowPolicy tells what happens to a signal delivered while the
+// channel of the context is full, because the previous signals were not
+// handled yet.
+type OverflowPolicy int
+
+const (
+	// Drop discards the new signal. This is what the signal package does,
+	// and the default.
+	Drop OverflowPolicy = iota
+
+	// Block waits for room in the channel. It is only useful with a custom
+	// notifier, set with WithNotifier, that sends signals synchronously: the
+	// signal package never waits.
+	Block
+
+	// Latest discards the oldest pending signal to make room for the new
+	// one.
+	Latest
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Drop:
+		return "drop"
+	case Block:
+		return "block"
+	case Latest:
+		return "latest"
+	}
+	return "unknown"
+}
+
+// WithOverflowPolicy sets what happens to a signal delivered while the
+// channel of the context is full. The notifier then sends signals to an
+// intermediate channel, from which they are forwarded according to policy.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(o *options) {
+		o.overflow = &policy
+	}
+}
+
+// forward sends the signals received from in to out according to p, until
+// in is closed or done is.
+func (p OverflowPolicy) forward(in <-chan os.Signal, out chan os.Signal, done <-chan struct{}) {
+	for {
+		select {
+		case sig, ok := <-in:
+			if !ok {
+				return
+			}
+			if !p.put(sig, out, done) {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// put sends sig to out according to p. It reports false if done was closed
+// while waiting.
+func (p OverflowPolicy) put(sig os.Signal, out chan os.Signal, done <-chan struct{}) bool {
+	switch p {
+	case Block:
+		select {
+		case out <- sig:
+		case <-done:
+			return false
+		}
+	case Latest:
+		for {
+			select {
+			case out <- sig:
+				return true
+			default:
+			}
+			select {
+			case <-out:
+			default:
+			}
+		}
+	default:
+		select {
+		case out <- sig:
+		default:
+		}
+	}
+	return true
+}
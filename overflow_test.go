@@ -0,0 +1,161 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOverflowPolicy(t *testing.T) {
+	sigs := []os.Signal{
+		TestSignal{Name: "a", Num: 1},
+		TestSignal{Name: "b", Num: 2},
+		TestSignal{Name: "c", Num: 3},
+	}
+	tests := []struct {
+		policy OverflowPolicy
+		want   []os.Signal
+	}{
+		{Drop, sigs[:1]},
+		{Latest, sigs[2:]},
+		{Block, sigs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			in := make(chan os.Signal, 1)
+			out := make(chan os.Signal, 1)
+			done := make(chan struct{})
+			go func() {
+				tt.policy.forward(in, out, make(chan struct{}))
+				close(done)
+			}()
+
+			// Nobody reads out while the signals are sent, as if the
+			// context was busy with the first one.
+			for _, sig := range sigs {
+				in <- sig
+			}
+			close(in)
+
+			if tt.policy != Block {
+				<-done
+			}
+			var got []os.Signal
+			for len(got) < len(tt.want) {
+				select {
+				case sig := <-out:
+					got = append(got, sig)
+				case <-time.After(time.Second):
+					t.Fatalf("timed out waiting for signals, got %v", got)
+				}
+			}
+			<-done
+			select {
+			case sig := <-out:
+				got = append(got, sig)
+			default:
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("observed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithOverflowPolicy(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithOverflowPolicy(Latest))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done")
+	}
+	stop()
+	if n.ch != c.(*signalCtx).in {
+		t.Errorf("expected the notifier to be given the intermediate channel")
+	}
+}
+
+func TestWithOverflowPolicyBurst(t *testing.T) {
+	a := TestSignal{Name: "a", Num: 1}
+	b := TestSignal{Name: "b", Num: 2}
+	c := TestSignal{Name: "c", Num: 3}
+	d := TestSignal{Name: "d", Num: 4}
+	end := TestSignal{Name: "end", Num: 5}
+	tests := []struct {
+		policy OverflowPolicy
+		want   []os.Signal
+	}{
+		{Drop, []os.Signal{a, b, end}},
+		{Latest, []os.Signal{a, d, end}},
+		{Block, []os.Signal{a, b, c, d, end}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			// The notifier sends synchronously, as Block requires.
+			registered := make(chan chan<- os.Signal, 1)
+			notify := func(ch chan<- os.Signal, _ ...os.Signal) { registered <- ch }
+			// The context is busy with a, and never canceled, while the
+			// burst arrives.
+			release := make(chan struct{})
+			busy := func(o *options) {
+				o.gates = append(o.gates, func(sig os.Signal, _ time.Time) bool {
+					if sig == a {
+						<-release
+					}
+					return false
+				})
+			}
+			ctx, stop := New(context.Background(), []os.Signal{a, b, c, d, end},
+				WithNotifier(notify, func(chan<- os.Signal) {}), WithOverflowPolicy(tt.policy), busy)
+			defer stop()
+			events := Events(ctx)
+			in := <-registered
+
+			in <- a
+			waitReceived(t, events)
+			for _, sig := range []os.Signal{b, c, d} {
+				in <- sig
+			}
+			// Let the signals reach the policy before the context handles
+			// them. With Block, d waits in the channel until it does.
+			for tt.policy != Block && len(in) > 0 {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+
+			// Once the survivors are handled, end shows that no other
+			// signal follows them.
+			got := []os.Signal{a}
+			next := func() {
+				t.Helper()
+				for {
+					select {
+					case e := <-events:
+						if e.Type == Received {
+							got = append(got, e.Signal)
+							return
+						}
+					case <-time.After(time.Second):
+						t.Fatalf("timed out waiting for signals, got %v", got)
+					}
+				}
+			}
+			for len(got) < len(tt.want)-1 {
+				next()
+			}
+			in <- end
+			next()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("observed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrPhaseTimeout is the error recorded for a shutdown phase that did
+	// not finish within its timeout.
+	ErrPhaseTimeout = errors.New("sigctx: shutdown phase timed out")
+
+	// ErrPhasesStarted is returned by AddPhaseTimeout once the shutdown
+	// phases have started running.
+	ErrPhasesStarted = errors.New("sigctx: shutdown phases already started")
+)
+
+// A PhaseError records the failure of a shutdown phase added with
+// AddPhaseTimeout.
+type PhaseError struct {
+	Phase string
+	Err   error
+}
+
+func (e *PhaseError) Error() string {
+	return "sigctx: shutdown phase " + e.Phase + ": " + e.Err.Error()
+}
+
+func (e *PhaseError) Unwrap() error { return e.Err }
+
+// WithAbortOnPhaseError makes the shutdown phases stop at the first phase
+// that fails or times out, skipping the following ones, if abort is true.
+// By default, all phases run.
+func WithAbortOnPhaseError(abort bool) Option {
+	return func(o *options) {
+		o.abortOnPhaseError = abort
+	}
+}
+
+type phase struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) error
+}
+
+// phases holds the shutdown phases of a signal context.
+type phases struct {
+	list    []phase
+	started bool
+	done    chan struct{}
+	err     error // set before done is closed
+}
+
+// AddPhaseTimeout adds a shutdown phase to the signal context of ctx. Once
+// the context is done, for whatever reason, the phases run one after the
+// other on a single goroutine, in the order they were added. Each phase
+// gets its own context, as returned by ShutdownContext, that expires after
+// timeout. A phase fails if fn returns an error, or times out if its context
+// expired; the failure is recorded as a *PhaseError, wrapping
+// ErrPhaseTimeout for a timeout, and the next phase runs unless the context
+// was created with WithAbortOnPhaseError(true). WaitPhases reports the
+// failures.
+//
+// AddPhaseTimeout returns ErrNotSignalContext if ctx has no signal context,
+// and ErrPhasesStarted if the phases already started running.
+func AddPhaseTimeout(ctx context.Context, name string, timeout time.Duration, fn func(ctx context.Context) error) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.phases == nil {
+		c.phases = &phases{done: make(chan struct{})}
+		context.AfterFunc(c, c.runPhases)
+	}
+	if c.phases.started {
+		return ErrPhasesStarted
+	}
+	c.phases.list = append(c.phases.list, phase{name: name, timeout: timeout, fn: fn})
+	return nil
+}
+
+func (c *signalCtx) runPhases() {
+	c.mu.Lock()
+	p := c.phases
+	p.started = true
+	c.mu.Unlock()
+
+	var errs []error
+	for _, ph := range p.list {
+		ctx, cancel := ShutdownContext(c, ph.timeout)
+		err := ph.fn(ctx)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ErrPhaseTimeout
+		}
+		cancel()
+		if err == nil {
+			continue
+		}
+		errs = append(errs, &PhaseError{Phase: ph.name, Err: err})
+		if c.opts.abortOnPhaseError {
+			break
+		}
+	}
+	p.err = errors.Join(errs...)
+	close(p.done)
+}
+
+// WaitPhases waits until the shutdown phases added to the signal context of
+// ctx with AddPhaseTimeout have run, and returns their failures joined with
+// errors.Join, or nil if they all succeeded. It returns immediately if ctx
+// has no signal context or no phases.
+func WaitPhases(ctx context.Context) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	p := c.phases
+	c.mu.Unlock()
+	if p == nil {
+		return nil
+	}
+	<-p.done
+	return p.err
+}
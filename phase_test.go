@@ -0,0 +1,73 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAddPhaseTimeout(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+
+	var slowErr error
+	var ran []string
+	AddPhaseTimeout(c, "drain", 10*time.Millisecond, func(ctx context.Context) error {
+		ran = append(ran, "drain")
+		<-ctx.Done()
+		slowErr = ctx.Err()
+		return ctx.Err()
+	})
+	AddPhaseTimeout(c, "flush", time.Second, func(ctx context.Context) error {
+		ran = append(ran, "flush")
+		return nil
+	})
+
+	Trigger(c, syscall.SIGTERM)
+	err := WaitPhases(c)
+	if slowErr != context.DeadlineExceeded {
+		t.Errorf("phase context error = %v, want %v", slowErr, context.DeadlineExceeded)
+	}
+	var pe *PhaseError
+	if !errors.As(err, &pe) || pe.Phase != "drain" || !errors.Is(err, ErrPhaseTimeout) {
+		t.Errorf("WaitPhases(c) = %v, want a timeout of phase drain", err)
+	}
+	if len(ran) != 2 {
+		t.Errorf("ran phases %v, want both", ran)
+	}
+	if err := AddPhaseTimeout(c, "late", time.Second, nil); err != ErrPhasesStarted {
+		t.Errorf("AddPhaseTimeout after the phases started = %v, want %v", err, ErrPhasesStarted)
+	}
+}
+
+func TestWithAbortOnPhaseError(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithAbortOnPhaseError(true))
+	defer stop()
+
+	errFailed := errors.New("failed")
+	ran := false
+	AddPhaseTimeout(c, "first", time.Second, func(context.Context) error { return errFailed })
+	AddPhaseTimeout(c, "second", time.Second, func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	stop()
+	if err := WaitPhases(c); !errors.Is(err, errFailed) {
+		t.Errorf("WaitPhases(c) = %v, want %v", err, errFailed)
+	}
+	if ran {
+		t.Errorf("expected the phase after a failure to be skipped")
+	}
+}
+
+func TestWaitPhasesWithoutPhases(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	defer stop()
+	if err := WaitPhases(c); err != nil {
+		t.Errorf("WaitPhases(c) = %v without phases, want nil", err)
+	}
+}
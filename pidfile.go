@@ -0,0 +1,30 @@
+package sigctx
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+)
+
+// WithPIDFile writes the process ID to path when the context is created, and
+// removes the file once the context is done. Failures to write or remove the
+// file are logged, to the logger set with WithLogger if any, rather than
+// returned, as they should not prevent the program from running. If the
+// parent is already done, no file is written.
+func WithPIDFile(path string) Option {
+	return func(o *options) {
+		o.setups = append(o.setups, func(c *signalCtx) {
+			pid := strconv.Itoa(os.Getpid()) + "\n"
+			if err := os.WriteFile(path, []byte(pid), 0o644); err != nil {
+				c.opts.warnLogger().Error("sigctx: cannot write PID file", "path", path, "err", err)
+			}
+		})
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			<-c.Done()
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				c.opts.warnLogger().Error("sigctx: cannot remove PID file", "path", path, "err", err)
+			}
+		})
+	}
+}
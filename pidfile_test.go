@@ -0,0 +1,64 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithPIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.pid")
+	_, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithPIDFile(path))
+	defer stop()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("PID file was not written: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(b)), strconv.Itoa(os.Getpid()); got != want {
+		t.Errorf("PID file contains %q, want %q", got, want)
+	}
+
+	stop()
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("os.Stat after stop = %v, want %v", err, fs.ErrNotExist)
+	}
+	stop()
+}
+
+func TestWithPIDFileCanceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.pid")
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithPIDFile(path))
+	defer stop()
+
+	Trigger(c, syscall.SIGTERM)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("PID file still exists after cancellation")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithPIDFileLogger(t *testing.T) {
+	var h recordingHandler
+	path := filepath.Join(t.TempDir(), "missing", "app.pid")
+	_, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithPIDFile(path), WithLogger(slog.New(&h)))
+	stop()
+
+	if lvl, ok := h.levels()["sigctx: cannot write PID file"]; !ok || lvl != slog.LevelError {
+		t.Errorf("write failure logged at %v, %v, want %v", lvl, ok, slog.LevelError)
+	}
+}
@@ -0,0 +1,64 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// workerPool is the shutdown barrier of a context from NotifyContextPool.
+type workerPool struct {
+	mu        sync.Mutex
+	remaining int
+	done      chan struct{}
+}
+
+// NotifyContextPool is like NotifyContext, for a pool of a fixed number of
+// workers that each call WorkerDone with ctx when they exit, typically once
+// ctx is done. The returned wait function blocks until all workers have
+// called WorkerDone and returns nil, or, once ctx is done, until the drain
+// timeout elapses and returns context.DeadlineExceeded.
+func NotifyContextPool(parent context.Context, workers int, signals ...os.Signal) (ctx context.Context, wait func() error, stop context.CancelFunc) {
+	c, stop := newSignalCtx(parent, signals, nil)
+	c.pool = &workerPool{remaining: workers, done: make(chan struct{})}
+	if workers <= 0 {
+		close(c.pool.done)
+	}
+	return c, c.waitWorkers, stop
+}
+
+// WorkerDone records that a worker of the pool of ctx, as returned by
+// NotifyContextPool, has finished. Calls beyond the number of workers, or
+// with a context that has no pool, are ignored.
+func WorkerDone(ctx context.Context) {
+	c, ok := fromContext(ctx)
+	if !ok || c.pool == nil {
+		return
+	}
+	p := c.pool
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.remaining <= 0 {
+		return
+	}
+	p.remaining--
+	if p.remaining == 0 {
+		close(p.done)
+	}
+}
+
+func (c *signalCtx) waitWorkers() error {
+	select {
+	case <-c.pool.done:
+		return nil
+	case <-c.Done():
+	}
+	ctx, cancel := c.drainContext()
+	defer cancel()
+	select {
+	case <-c.pool.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
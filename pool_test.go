@@ -0,0 +1,48 @@
+package sigctx
+
+import (
+	"context"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextPool(t *testing.T) {
+	c, wait, stop := NotifyContextPool(context.Background(), 3, syscall.SIGTERM)
+	defer stop()
+
+	var finished atomic.Int32
+	for i := 0; i < 3; i++ {
+		go func() {
+			defer WorkerDone(c)
+			<-c.Done()
+			time.Sleep(5 * time.Millisecond)
+			finished.Add(1)
+		}()
+	}
+
+	Trigger(c, syscall.SIGTERM)
+	if err := wait(); err != nil {
+		t.Fatalf("wait() = %v, want nil", err)
+	}
+	if n := finished.Load(); n != 3 {
+		t.Errorf("wait returned after %d workers finished, want 3", n)
+	}
+	WorkerDone(c)
+	if err := wait(); err != nil {
+		t.Errorf("wait() = %v after an extra WorkerDone, want nil", err)
+	}
+}
+
+func TestNotifyContextPoolDrainTimeout(t *testing.T) {
+	c, wait, stop := NotifyContextPool(context.Background(), 2, syscall.SIGTERM)
+	defer stop()
+	c.(*signalCtx).opts.drainTimeout = 10 * time.Millisecond
+
+	WorkerDone(c)
+	stop()
+	if err := wait(); err != context.DeadlineExceeded {
+		t.Errorf("wait() = %v with a stuck worker, want %v", err, context.DeadlineExceeded)
+	}
+}
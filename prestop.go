@@ -0,0 +1,52 @@
+package sigctx
+
+import (
+	"errors"
+	"net"
+	"net/http"
+)
+
+// ErrPreStop is the cause of a context canceled through the endpoint
+// started by WithPreStopEndpoint.
+var ErrPreStop = errors.New("sigctx: preStop endpoint called")
+
+// WithPreStopEndpoint makes the context listen for HTTP requests on addr, and
+// cancel with cause ErrPreStop when it receives a POST request for /preStop,
+// for environments such as Kubernetes where a preStop hook calls an
+// endpoint instead of sending a signal. The listener is closed by stop. If
+// it cannot be opened, the error is logged, and the context only responds
+// to signals.
+func WithPreStopEndpoint(addr string) Option {
+	return func(o *options) {
+		var srv *http.Server
+		var ln net.Listener
+		o.setups = append(o.setups, func(c *signalCtx) {
+			var err error
+			ln, err = net.Listen("tcp", addr)
+			if err != nil {
+				c.opts.warnLogger().Error("sigctx: cannot listen for preStop requests", "addr", addr, "err", err)
+				return
+			}
+			c.opts.preStopAddr = ln.Addr()
+			mux := http.NewServeMux()
+			mux.HandleFunc("/preStop", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					w.Header().Set("Allow", http.MethodPost)
+					http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+					return
+				}
+				c.markCanceled()
+				c.cancel(ErrPreStop)
+			})
+			srv = &http.Server{Handler: mux}
+		})
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			if srv == nil {
+				return
+			}
+			go srv.Serve(ln)
+			<-c.stoppingChan()
+			srv.Close()
+		})
+	}
+}
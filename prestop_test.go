@@ -0,0 +1,52 @@
+package sigctx
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithPreStopEndpoint(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithPreStopEndpoint("127.0.0.1:0"))
+	defer stop()
+	url := "http://" + c.(*signalCtx).opts.preStopAddr.String() + "/preStop"
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET /preStop: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET /preStop: status %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v after a GET, want nil", err)
+	}
+
+	resp, err = http.Post(url, "text/plain", nil)
+	if err != nil {
+		t.Fatalf("POST /preStop: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("POST /preStop: status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after POST /preStop")
+	}
+	if cause := context.Cause(c); cause != ErrPreStop {
+		t.Errorf("context.Cause(c) = %v, want %v", cause, ErrPreStop)
+	}
+
+	stop()
+	client := &http.Client{Timeout: time.Second}
+	if resp, err := client.Post(url, "text/plain", nil); err == nil {
+		resp.Body.Close()
+		t.Errorf("expected the endpoint to be closed after stop")
+	}
+}
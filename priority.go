@@ -0,0 +1,59 @@
+package sigctx
+
+import (
+	"context"
+	"sort"
+)
+
+type shutdownHook struct {
+	priority int
+	fn       func(ctx context.Context)
+}
+
+// OnShutdownPriority registers fn to be called once the signal context of
+// ctx is done, for whatever reason. The callbacks of a context run one after
+// the other on a single goroutine, in increasing order of priority, and in
+// order of registration for equal priorities, which gives a deterministic
+// teardown across independent modules: stop accepting requests, drain, close
+// the database, flush logs. Each callback receives a drain context, as
+// returned by ShutdownContext with the timeout set by WithDrainTimeout.
+//
+// A callback registered after the callbacks already ran is called right
+// away on its own goroutine. OnShutdownPriority returns ErrNotSignalContext
+// if ctx has no signal context.
+func OnShutdownPriority(ctx context.Context, priority int, fn func(ctx context.Context)) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	h := shutdownHook{priority: priority, fn: fn}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hooksRan {
+		go c.runShutdownHooks([]shutdownHook{h})
+		return nil
+	}
+	if len(c.hooks) == 0 {
+		context.AfterFunc(c, func() {
+			c.mu.Lock()
+			hooks := c.hooks
+			c.hooks = nil
+			c.hooksRan = true
+			c.mu.Unlock()
+			c.runShutdownHooks(hooks)
+		})
+	}
+	c.hooks = append(c.hooks, h)
+	return nil
+}
+
+func (c *signalCtx) runShutdownHooks(hooks []shutdownHook) {
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hooks[i].priority < hooks[j].priority
+	})
+	ctx, cancel := c.drainContext()
+	defer cancel()
+	for _, h := range hooks {
+		h.fn(ctx)
+	}
+}
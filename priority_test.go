@@ -0,0 +1,66 @@
+package sigctx
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOnShutdownPriority(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	for _, p := range []int{30, 10, 20} {
+		p := p
+		wg.Add(1)
+		err := OnShutdownPriority(c, p, func(ctx context.Context) {
+			defer wg.Done()
+			if err := ctx.Err(); err != nil {
+				t.Errorf("priority %d: drain context is done: %v", p, err)
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+		})
+		if err != nil {
+			t.Fatalf("OnShutdownPriority = %v", err)
+		}
+	}
+
+	Trigger(c, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the shutdown callbacks")
+	}
+	if want := []int{10, 20, 30}; !reflect.DeepEqual(order, want) {
+		t.Errorf("callbacks ran in order %v, want %v", order, want)
+	}
+
+	late := make(chan struct{})
+	OnShutdownPriority(c, 0, func(ctx context.Context) { close(late) })
+	select {
+	case <-late:
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for a callback registered after shutdown")
+	}
+}
+
+func TestOnShutdownPriorityNotSignalContext(t *testing.T) {
+	if err := OnShutdownPriority(context.Background(), 0, func(context.Context) {}); err != ErrNotSignalContext {
+		t.Errorf("OnShutdownPriority(context.Background()) = %v, want %v", err, ErrNotSignalContext)
+	}
+}
@@ -0,0 +1,30 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithProcessGroup(t *testing.T) {
+	pgid, err := syscall.Getpgid(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// SIGWINCH is ignored by default, so it does not disturb the other
+	// processes of the group, such as the go command running the test.
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGWINCH}, WithProcessGroup())
+	defer stop()
+
+	if err := syscall.Kill(-pgid, syscall.SIGWINCH); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for context to be done after signalling the process group")
+	}
+}
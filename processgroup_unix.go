@@ -0,0 +1,18 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+// WithProcessGroup asks the context to cancel only on signals delivered to
+// the process group, as with kill(-pgid, sig), rather than to the process
+// alone.
+//
+// Telling the two apart requires the siginfo of the signal, which the signal
+// package does not expose. Until it does, WithProcessGroup falls back to
+// cancelling on every listed signal, whichever way it was delivered, so that
+// code using it keeps shutting down when asked to.
+func WithProcessGroup() Option {
+	return func(o *options) {
+		o.processGroup = true
+	}
+}
@@ -0,0 +1,32 @@
+package sigctx
+
+import "context"
+
+type progress struct {
+	done, total int
+}
+
+// ReportProgress records that done out of total units of shutdown work have
+// completed, for example the number of connections drained so far. Only the
+// latest report is kept. It does nothing if ctx was not created by this
+// package.
+func ReportProgress(ctx context.Context, done, total int) {
+	if c, ok := fromContext(ctx); ok {
+		c.progress.Store(&progress{done: done, total: total})
+	}
+}
+
+// Progress returns the values of the latest call to ReportProgress on ctx, or
+// on a context sharing its signal context. It returns 0, 0 if nothing was
+// reported.
+func Progress(ctx context.Context) (done, total int) {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return 0, 0
+	}
+	p := c.progress.Load()
+	if p == nil {
+		return 0, 0
+	}
+	return p.done, p.total
+}
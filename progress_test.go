@@ -0,0 +1,35 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+
+	if done, total := Progress(c); done != 0 || total != 0 {
+		t.Errorf("Progress = %d/%d before any report, want 0/0", done, total)
+	}
+
+	child := context.WithValue(c, requestIDKey{}, "req-1")
+	ReportProgress(child, 3, 10)
+	if done, total := Progress(c); done != 3 || total != 10 {
+		t.Errorf("Progress = %d/%d, want 3/10", done, total)
+	}
+	stop()
+	ReportProgress(c, 10, 10)
+	if done, total := Progress(child); done != 10 || total != 10 {
+		t.Errorf("Progress = %d/%d, want 10/10", done, total)
+	}
+}
+
+func TestProgressNotSignalContext(t *testing.T) {
+	ctx := context.Background()
+	ReportProgress(ctx, 1, 2)
+	if done, total := Progress(ctx); done != 0 || total != 0 {
+		t.Errorf("Progress = %d/%d, want 0/0", done, total)
+	}
+}
@@ -0,0 +1,46 @@
+package sigctx
+
+import (
+	"os"
+	"time"
+)
+
+// WithQueueDrain holds back the cancellation by a signal while the work
+// functions remaining in queue are received and run, in order, so that
+// queued jobs finish before the program quits. The context is canceled once
+// queue is empty or closed, or maxDrain after the signal arrived, whichever
+// happens first; a job that is running when maxDrain elapses keeps running
+// in its own goroutine, but no further job is started. Calling stop during
+// the drain also ends it.
+func WithQueueDrain(queue <-chan func(), maxDrain time.Duration) Option {
+	return func(o *options) {
+		o.beforeCancel = append(o.beforeCancel, func(c *signalCtx, _ os.Signal) {
+			t := time.NewTimer(maxDrain)
+			defer t.Stop()
+			for {
+				var job func()
+				select {
+				case j, ok := <-queue:
+					if !ok {
+						return
+					}
+					job = j
+				default:
+					return
+				}
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					job()
+				}()
+				select {
+				case <-done:
+				case <-t.C:
+					return
+				case <-c.Done():
+					return
+				}
+			}
+		})
+	}
+}
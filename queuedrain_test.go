@@ -0,0 +1,69 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithQueueDrain(t *testing.T) {
+	queue := make(chan func(), 3)
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithQueueDrain(queue, time.Second))
+	defer stop()
+	var ran []bool
+	for i := 0; i < 3; i++ {
+		queue <- func() { ran = append(ran, c.Err() == nil) }
+	}
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after draining")
+	}
+	if len(ran) != 3 {
+		t.Fatalf("ran %d jobs, want 3", len(ran))
+	}
+	for i, live := range ran {
+		if !live {
+			t.Errorf("job %d ran after cancellation", i)
+		}
+	}
+}
+
+func TestWithQueueDrainBudget(t *testing.T) {
+	queue := make(chan func(), 3)
+	release := make(chan struct{})
+	defer close(release)
+	var ran atomic.Int32
+	for i := 0; i < 3; i++ {
+		queue <- func() {
+			ran.Add(1)
+			<-release
+		}
+	}
+	const maxDrain = 10 * time.Millisecond
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithQueueDrain(queue, maxDrain))
+	defer stop()
+
+	// The first job blocks until the end of the test, so the context is
+	// only done if the drain gives up on it after maxDrain.
+	start := time.Now()
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after the drain budget")
+	}
+	if d := time.Since(start); d < maxDrain {
+		t.Errorf("context was done %v after the signal, want at least %v", d, maxDrain)
+	}
+	if got := ran.Load(); got != 1 {
+		t.Errorf("ran %d jobs, want 1 within the drain budget", got)
+	}
+}
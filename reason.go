@@ -0,0 +1,186 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+)
+
+// A Reason tells why a signal context was canceled.
+type Reason int
+
+const (
+	// ReasonNone is reported for contexts that are not done, or that were
+	// canceled for a reason not listed here, such as WithWatchFile.
+	ReasonNone Reason = iota
+
+	// ReasonSignal is reported for contexts canceled by a signal.
+	ReasonSignal
+
+	// ReasonParent is reported for contexts canceled by their parent.
+	ReasonParent
+
+	// ReasonStop is reported for contexts canceled by their stop function.
+	ReasonStop
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonSignal:
+		return "signal"
+	case ReasonParent:
+		return "parent"
+	case ReasonStop:
+		return "stop"
+	}
+	return "unknown"
+}
+
+// parseReason returns the Reason whose String method returns s.
+func parseReason(s string) (Reason, bool) {
+	for r := ReasonNone; r <= ReasonStop; r++ {
+		if r.String() == s {
+			return r, true
+		}
+	}
+	return ReasonNone, false
+}
+
+// defaultReasonPriority is the priority order used unless overridden with
+// WithReasonPriority.
+var defaultReasonPriority = []Reason{ReasonSignal, ReasonParent, ReasonStop}
+
+// WithReasonPriority sets the order, highest priority first, in which
+// reasons win when several triggers race, for example when a signal arrives
+// while stop is being called. The default order is ReasonSignal,
+// ReasonParent, ReasonStop. Reasons missing from order rank below the listed
+// ones.
+//
+// The cause returned by Cause follows the same order: it is the cause of the
+// trigger that won, even if another trigger canceled the context first.
+// context.Cause still returns the cause of the trigger that came first.
+func WithReasonPriority(order []Reason) Option {
+	return func(o *options) {
+		o.reasonPriority = append([]Reason(nil), order...)
+	}
+}
+
+// rank returns the rank of r in o's priority order, lower ranking higher.
+func (o *options) rank(r Reason) int {
+	for i, p := range o.reasonPriority {
+		if p == r {
+			return i
+		}
+	}
+	return len(o.reasonPriority)
+}
+
+// setReason records r unless a reason that ranks at least as high was
+// recorded first, or the reason is settled. It reports whether r was
+// recorded.
+func (c *signalCtx) setReason(r Reason) bool {
+	for {
+		if c.settled.Load() {
+			return false
+		}
+		cur := Reason(c.reason.Load())
+		if cur != ReasonNone && c.opts.rank(cur) <= c.opts.rank(r) {
+			return false
+		}
+		if c.reason.CompareAndSwap(int32(cur), int32(r)) {
+			return true
+		}
+	}
+}
+
+// CancelReason returns why the signal context of ctx was canceled. When
+// several triggers race, the one ranking highest as set by
+// WithReasonPriority wins, whichever came first. It returns ReasonNone if
+// ctx was not created by this package or is not done.
+//
+// The reason of a signal or of stop is recorded before the context is
+// done, and that of the parent is known as soon as it is, so CancelReason
+// never returns ReasonNone once Done is closed, unless an option canceled
+// the context.
+func CancelReason(ctx context.Context) Reason {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ReasonNone
+	}
+	r := Reason(c.reason.Load())
+	if r == ReasonNone && c.Err() != nil && c.parent.Err() != nil {
+		// The goroutine watching the signals did not notice yet.
+		c.setReason(ReasonParent)
+		r = Reason(c.reason.Load())
+	}
+	return r
+}
+
+// Cause is like context.Cause, but when several triggers race to cancel the
+// signal context of ctx, it returns the cause of the one that wins as set by
+// WithReasonPriority, as CancelReason reports it, even if another trigger
+// canceled the context first. It returns context.Cause(ctx) if ctx was
+// canceled for another reason, such as an option or, for a context derived
+// from the signal context, its own cancel function.
+func Cause(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	c, ok := fromContext(ctx)
+	if !ok || cause == nil || cause != context.Cause(c) || !c.isReasonCause(cause) {
+		return cause
+	}
+	if won := c.reasonCause(CancelReason(c)); won != nil {
+		return won
+	}
+	return cause
+}
+
+// ErrSignal matches, with errors.Is, the *SignalError that causes a context
+// canceled by a signal. It is also the error of ReasonSignal.
+var ErrSignal = errors.New("sigctx: signal received")
+
+// Err returns a sentinel error for r, so that reasons can be handled like
+// causes: ErrSignal for ReasonSignal, ErrParentDone for ReasonParent,
+// ErrStopped for ReasonStop, and nil for ReasonNone.
+func (r Reason) Err() error {
+	switch r {
+	case ReasonSignal:
+		return ErrSignal
+	case ReasonParent:
+		return ErrParentDone
+	case ReasonStop:
+		return ErrStopped
+	}
+	return nil
+}
+
+// reasonCause returns the cause of the trigger of r, or nil if it is not
+// known.
+func (c *signalCtx) reasonCause(r Reason) error {
+	switch r {
+	case ReasonSignal:
+		if err := c.signalCause.Load(); err != nil {
+			return *err
+		}
+	case ReasonParent:
+		if c.parent.Err() != nil {
+			return context.Cause(c.parent)
+		}
+	case ReasonStop:
+		if err := c.stopCause.Load(); err != nil {
+			return *err
+		}
+	}
+	return nil
+}
+
+// isReasonCause reports whether err is the cause of the trigger of a
+// Reason.
+func (c *signalCtx) isReasonCause(err error) bool {
+	for r := ReasonSignal; r <= ReasonStop; r++ {
+		if cause := c.reasonCause(r); cause != nil && cause == err {
+			return true
+		}
+	}
+	return false
+}
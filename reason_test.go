@@ -0,0 +1,199 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCancelReason(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		var n fakeNotifier
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option())
+		defer stop()
+		if got := CancelReason(c); got != ReasonNone {
+			t.Errorf("CancelReason before cancellation = %v, want %v", got, ReasonNone)
+		}
+		n.send(syscall.SIGTERM)
+		<-c.Done()
+		stop()
+		if got := CancelReason(c); got != ReasonSignal {
+			t.Errorf("CancelReason = %v, want %v", got, ReasonSignal)
+		}
+	})
+	t.Run("parent", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		c, stop := New(parent, []os.Signal{syscall.SIGTERM})
+		defer stop()
+		cancelParent()
+		<-c.Done()
+		stop()
+		if got := CancelReason(c); got != ReasonParent {
+			t.Errorf("CancelReason = %v, want %v", got, ReasonParent)
+		}
+	})
+	t.Run("premature parent", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		cancelParent()
+		c, stop := New(parent, []os.Signal{syscall.SIGTERM})
+		stop()
+		if got := CancelReason(c); got != ReasonParent {
+			t.Errorf("CancelReason = %v, want %v", got, ReasonParent)
+		}
+	})
+	t.Run("stop", func(t *testing.T) {
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+		stop()
+		if got := CancelReason(c); got != ReasonStop {
+			t.Errorf("CancelReason = %v, want %v", got, ReasonStop)
+		}
+	})
+}
+
+func TestReasonPriorityRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var n fakeNotifier
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option())
+
+		var sent bool
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			sent = n.send(syscall.SIGTERM)
+		}()
+		go func() {
+			defer wg.Done()
+			stop()
+		}()
+		wg.Wait()
+
+		want := ReasonStop
+		if sent {
+			want = ReasonSignal
+		}
+		if got := CancelReason(c); got != want {
+			t.Fatalf("iteration %d: CancelReason = %v, want %v (signal sent: %v)", i, got, want, sent)
+		}
+		if cause := Cause(c); want == ReasonSignal && !IsSignal(cause, syscall.SIGTERM) {
+			t.Fatalf("iteration %d: Cause(c) = %v, want the signal", i, cause)
+		} else if want == ReasonStop && cause != context.Canceled {
+			t.Fatalf("iteration %d: Cause(c) = %v, want %v", i, cause, context.Canceled)
+		}
+	}
+}
+
+func TestWithReasonPriority(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	var n fakeNotifier
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, n.option(), WithReasonPriority([]Reason{ReasonParent, ReasonSignal}))
+	defer stop()
+
+	// Deliver the signal and cancel the parent before the listener can
+	// run: whichever it sees first, the parent must win.
+	c.(*signalCtx).mu.Lock()
+	n.send(syscall.SIGTERM)
+	cancelParent()
+	c.(*signalCtx).mu.Unlock()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done")
+	}
+	stop()
+	if got := CancelReason(c); got != ReasonParent {
+		t.Errorf("CancelReason = %v, want %v", got, ReasonParent)
+	}
+	if got := Cause(c); got != context.Canceled {
+		t.Errorf("Cause(c) = %v, want the cause of the parent", got)
+	}
+}
+
+func TestReasonPriorityCause(t *testing.T) {
+	parent, cancelParent := context.WithCancelCause(context.Background())
+	var n fakeNotifier
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, n.option())
+	defer stop()
+	derived, cancel := context.WithCancel(c)
+	defer cancel()
+	own, cancelOwn := context.WithCancelCause(c)
+	cancelOwn(errMaintenance)
+
+	// The parent cancels c before the listener sees the pending signal,
+	// which outranks it.
+	c.(*signalCtx).mu.Lock()
+	n.send(syscall.SIGTERM)
+	cancelParent(errParentGone)
+	c.(*signalCtx).mu.Unlock()
+
+	<-c.Done()
+	stop()
+	if got := CancelReason(c); got != ReasonSignal {
+		t.Fatalf("CancelReason = %v, want %v", got, ReasonSignal)
+	}
+	if got := context.Cause(c); got != errParentGone {
+		t.Errorf("context.Cause(c) = %v, want %v", got, errParentGone)
+	}
+	if got := Cause(c); !IsSignal(got, syscall.SIGTERM) {
+		t.Errorf("Cause(c) = %v, want the signal", got)
+	}
+	if got := Cause(derived); !IsSignal(got, syscall.SIGTERM) {
+		t.Errorf("Cause(derived) = %v, want the signal", got)
+	}
+	if got := Cause(own); got != errMaintenance {
+		t.Errorf("Cause(own) = %v, want %v", got, errMaintenance)
+	}
+}
+
+func TestReasonErr(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		var n fakeNotifier
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option())
+		defer stop()
+		if err := CancelReason(c).Err(); err != nil {
+			t.Errorf("CancelReason(c).Err() = %v before cancellation, want nil", err)
+		}
+		n.send(syscall.SIGTERM)
+		<-c.Done()
+		if err := CancelReason(c).Err(); err != ErrSignal {
+			t.Errorf("CancelReason(c).Err() = %v, want %v", err, ErrSignal)
+		}
+		if cause := context.Cause(c); !errors.Is(cause, ErrSignal) {
+			t.Errorf("context.Cause(c) = %v, want it to match %v", cause, ErrSignal)
+		}
+	})
+	t.Run("parent", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		c, stop := New(parent, []os.Signal{syscall.SIGTERM})
+		defer stop()
+		cancelParent()
+		<-c.Done()
+		if err := CancelReason(c).Err(); err != ErrParentDone {
+			t.Errorf("CancelReason(c).Err() = %v once done, want %v", err, ErrParentDone)
+		}
+	})
+	t.Run("premature parent", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		cancelParent()
+		c, stop := New(parent, []os.Signal{syscall.SIGTERM})
+		defer stop()
+		if err := CancelReason(c).Err(); err != ErrParentDone {
+			t.Errorf("CancelReason(c).Err() = %v, want %v", err, ErrParentDone)
+		}
+	})
+	t.Run("stop", func(t *testing.T) {
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+		stop()
+		if err := CancelReason(c).Err(); err != ErrStopped {
+			t.Errorf("CancelReason(c).Err() = %v, want %v", err, ErrStopped)
+		}
+		if cause := context.Cause(c); errors.Is(cause, ErrSignal) {
+			t.Errorf("context.Cause(c) = %v matches %v after stop", cause, ErrSignal)
+		}
+	})
+}
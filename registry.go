@@ -0,0 +1,48 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrNotRegistered is returned by Registry.Get for a name under which no
+// configuration was registered.
+var ErrNotRegistered = errors.New("sigctx: no signal configuration registered")
+
+// A Registry holds named signal configurations, so that dependency injection
+// frameworks can obtain preconfigured signal contexts by name, away from
+// where they are configured. The zero value is an empty registry ready to
+// use. A Registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.Mutex
+	signals map[string][]os.Signal
+}
+
+// Register registers the configuration name, replacing any configuration
+// registered under the same name.
+func (r *Registry) Register(name string, signals ...os.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.signals == nil {
+		r.signals = make(map[string][]os.Signal)
+	}
+	r.signals[name] = append([]os.Signal(nil), signals...)
+}
+
+// Get returns a signal context derived from parent, as returned by
+// NotifyContext with the signals of the configuration name. It returns an
+// error wrapping ErrNotRegistered if no configuration was registered under
+// name.
+func (r *Registry) Get(name string, parent context.Context) (context.Context, context.CancelFunc, error) {
+	r.mu.Lock()
+	signals, ok := r.signals[name]
+	r.mu.Unlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("%w as %q", ErrNotRegistered, name)
+	}
+	ctx, stop := NotifyContext(parent, signals...)
+	return ctx, stop, nil
+}
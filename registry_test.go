@@ -0,0 +1,34 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	var r Registry
+	r.Register("main", syscall.SIGTERM)
+
+	c, stop, err := r.Get("main", context.Background())
+	if err != nil {
+		t.Fatalf("Get = %v", err)
+	}
+	defer stop()
+	if want, got := "signal.NotifyContext(context.Background, [terminated])", c.(interface{ String() string }).String(); got != want {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+	Trigger(c, syscall.SIGTERM)
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
+
+func TestRegistryUnknown(t *testing.T) {
+	var r Registry
+	r.Register("main", syscall.SIGTERM)
+	if _, _, err := r.Get("other", context.Background()); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Get of an unknown name = %v, want %v", err, ErrNotRegistered)
+	}
+}
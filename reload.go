@@ -0,0 +1,74 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// reloadBuffer is the capacity of the channel returned by
+// NotifyContextReload.
+const reloadBuffer = 16
+
+// NotifyContextReload is like NotifyContext for the signals in stopSignals,
+// but the signals in reloadSignals, conventionally SIGHUP, do not cancel the
+// context: they are sent on the returned reloads channel instead, so that a
+// daemon can reload its configuration. The reloads channel is closed by
+// stop, which unregisters both sets of signals. Reload signals that arrive
+// after the context is done are not delivered, and, like the signal package,
+// the context does not block on the channel: reloads are dropped when it
+// already holds 16 of them.
+func NotifyContextReload(parent context.Context, reloadSignals []os.Signal, stopSignals []os.Signal) (ctx context.Context, reloads <-chan os.Signal, stop context.CancelFunc) {
+	return newReloadCtx(parent, newReloader(), reloadSignals, stopSignals, nil)
+}
+
+func newReloadCtx(parent context.Context, r *reloader, reloadSignals, stopSignals []os.Signal, opts []Option) (*signalCtx, <-chan os.Signal, context.CancelFunc) {
+	signals := append(append([]os.Signal(nil), stopSignals...), reloadSignals...)
+	opts = append(opts, func(o *options) {
+		o.gates = append(o.gates, func(sig os.Signal, _ time.Time) bool {
+			if len(reloadSignals) == 0 || !wants(reloadSignals, sig) {
+				return true
+			}
+			r.send(sig)
+			return false
+		})
+	})
+	c, stop := newSignalCtx(parent, signals, opts)
+	return c, r.ch, func() {
+		stop()
+		r.close()
+	}
+}
+
+// reloader delivers reload signals on ch until it is closed.
+type reloader struct {
+	mu     sync.Mutex
+	ch     chan os.Signal
+	closed bool
+}
+
+func newReloader() *reloader {
+	return &reloader{ch: make(chan os.Signal, reloadBuffer)}
+}
+
+func (r *reloader) send(sig os.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.ch <- sig:
+	default:
+	}
+}
+
+func (r *reloader) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.closed {
+		r.closed = true
+		close(r.ch)
+	}
+}
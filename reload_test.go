@@ -0,0 +1,55 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextReload(t *testing.T) {
+	var n fakeNotifier
+	c, reloads, stop := newReloadCtx(context.Background(), newReloader(), []os.Signal{syscall.SIGINT}, []os.Signal{syscall.SIGTERM}, []Option{n.option()})
+	defer stop()
+
+	n.mu.Lock()
+	registered := n.signals
+	n.mu.Unlock()
+	if !wants(registered, syscall.SIGINT) || !wants(registered, syscall.SIGTERM) {
+		t.Fatalf("registered %v, want SIGINT and SIGTERM", registered)
+	}
+
+	for i := 0; i < 3; i++ {
+		n.send(syscall.SIGINT)
+		select {
+		case sig := <-reloads:
+			if sig != syscall.SIGINT {
+				t.Errorf("reload %d: got %v, want %v", i, sig, syscall.SIGINT)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for reload %d", i)
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v after reloads, want nil", err)
+	}
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+
+	stop()
+	if !n.stopped {
+		t.Errorf("expected stop to unregister the signals")
+	}
+	if _, ok := <-reloads; ok {
+		t.Errorf("expected the reloads channel to be closed after stop")
+	}
+}
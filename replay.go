@@ -0,0 +1,18 @@
+package sigctx
+
+// replayBufferSize is the capacity of the signal channel with
+// WithReplayBuffer.
+const replayBufferSize = 16
+
+// WithReplayBuffer guarantees that signals delivered before the context
+// starts watching for them, for example synchronously by a notifier set with
+// WithNotifier while it registers the channel, are kept and handled in order
+// as soon as the context starts watching, up to 16 of them. Without it, only
+// the first such signal is kept.
+func WithReplayBuffer() Option {
+	return func(o *options) {
+		if o.bufferSize < replayBufferSize {
+			o.bufferSize = replayBufferSize
+		}
+	}
+}
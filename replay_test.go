@@ -0,0 +1,64 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// eagerNotify is a notifier that delivers n signals while registering.
+func eagerNotify(n int) Option {
+	return WithNotifier(func(c chan<- os.Signal, sig ...os.Signal) {
+		for i := 0; i < n; i++ {
+			select {
+			case c <- sig[0]:
+			default:
+			}
+		}
+	}, func(chan<- os.Signal) {})
+}
+
+func TestWithReplayBuffer(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, eagerNotify(1), WithReplayBuffer())
+	defer stop()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a signal delivered at construction to cancel")
+	}
+	if !IsSignal(context.Cause(c), syscall.SIGTERM) {
+		t.Errorf("context.Cause(c) = %v, want SIGTERM", context.Cause(c))
+	}
+}
+
+func TestWithReplayBufferBurst(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		cancel bool
+	}{
+		{"without", nil, false},
+		{"with", []Option{WithReplayBuffer()}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{eagerNotify(3), WithBurst(3, time.Minute)}, tt.opts...)
+			c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, opts...)
+			defer stop()
+
+			select {
+			case <-c.Done():
+				if !tt.cancel {
+					t.Errorf("expected signals beyond the buffer to be dropped")
+				}
+			case <-time.After(50 * time.Millisecond):
+				if tt.cancel {
+					t.Errorf("expected all three signals delivered at construction to be replayed")
+				}
+			}
+		})
+	}
+}
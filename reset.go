@@ -0,0 +1,48 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// NotifyContextReset is like NotifyContext, but the stop function also
+// resets the listed signals to their default behavior, as with WithReset.
+func NotifyContextReset(parent context.Context, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
+	return newSignalCtx(parent, signals, []Option{WithReset()})
+}
+
+// WithReset makes the stop function call signal.Reset for the listed
+// signals after unregistering the context.
+//
+// By default, stop calls signal.Stop, which only unregisters the channel of
+// the context: signals listed by other calls to signal.Notify or
+// NotifyContext keep being delivered to them, and the default behavior only
+// comes back once no channel is left. signal.Reset instead undoes every
+// signal.Notify and signal.Ignore for the signals, so the operating system
+// default applies again right away, whoever else was listening. This is
+// what a program that is about to re-exec itself, or to hand a signal over
+// to its default action, wants, but it also silences every other listener
+// of the signals in the process, including other contexts.
+func WithReset() Option {
+	return func(o *options) {
+		o.reset = true
+	}
+}
+
+// WithOnStop makes the stop function call fn right after it unregisters the
+// signals, and resets them if WithReset is given, before it cancels the
+// context.
+//
+// signal.Stop only removes the channel of the context from the signal
+// package, which then restores the behavior the signals had before Go
+// handled them. It cannot however restore a handler that a cgo library
+// installed after the program started, since the Go runtime overwrote it.
+// Code that diverts signals only during a critical section can use fn to
+// reinstall such a handler as soon as the context no longer needs the
+// signals. Several functions are called in the order of their options,
+// each of them once, even if stop is called several times.
+func WithOnStop(fn func()) Option {
+	return func(o *options) {
+		o.onStop = append(o.onStop, fn)
+	}
+}
@@ -0,0 +1,42 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+func TestWithOnStop(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		seq []string
+	)
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		seq = append(seq, s)
+	}
+	var c context.Context
+	notify := func(chan<- os.Signal, ...os.Signal) {}
+	stopNotify := func(chan<- os.Signal) { record("unregistered") }
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM},
+		WithNotifier(notify, stopNotify),
+		WithOnStop(func() {
+			if c.Err() != nil {
+				record("on stop after cancel")
+				return
+			}
+			record("on stop")
+		}),
+		WithOnStop(func() { record("second on stop") }))
+	stop()
+	stop()
+
+	want := []string{"unregistered", "on stop", "second on stop"}
+	if !reflect.DeepEqual(seq, want) {
+		t.Errorf("stop ran %q, want %q", seq, want)
+	}
+}
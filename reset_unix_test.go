@@ -0,0 +1,65 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// These tests use SIGWINCH, whose default behavior is to be ignored, so that
+// it is safe to deliver once the handling of the signal is reset.
+
+func TestNotifyContextReset(t *testing.T) {
+	signal.Ignore(syscall.SIGWINCH)
+	defer signal.Reset(syscall.SIGWINCH)
+	if !signal.Ignored(syscall.SIGWINCH) {
+		t.Fatalf("expected SIGWINCH to be ignored")
+	}
+
+	_, stop := NotifyContextReset(context.Background(), syscall.SIGWINCH)
+	if signal.Ignored(syscall.SIGWINCH) {
+		t.Errorf("expected SIGWINCH not to be ignored while the context listens to it")
+	}
+	stop()
+	if signal.Ignored(syscall.SIGWINCH) {
+		t.Errorf("expected SIGWINCH not to be ignored after stop reset it")
+	}
+}
+
+func TestNotifyContextResetOtherListeners(t *testing.T) {
+	other := make(chan os.Signal, 1)
+	signal.Notify(other, syscall.SIGWINCH)
+	defer signal.Stop(other)
+
+	_, stop := NotifyContextReset(context.Background(), syscall.SIGWINCH)
+	stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGWINCH)
+	select {
+	case <-other:
+		t.Errorf("expected stop to reset SIGWINCH for every listener")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNotifyContextStopKeepsOtherListeners(t *testing.T) {
+	other := make(chan os.Signal, 1)
+	signal.Notify(other, syscall.SIGWINCH)
+	defer signal.Stop(other)
+
+	_, stop := NotifyContext(context.Background(), syscall.SIGWINCH)
+	stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGWINCH)
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Errorf("expected stop without reset to keep delivering SIGWINCH to other listeners")
+	}
+}
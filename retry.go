@@ -0,0 +1,37 @@
+package sigctx
+
+import (
+	"context"
+	"time"
+)
+
+// Retry calls fn until it returns nil, at most attempts times, waiting
+// backoff before the second call and twice as long before each following
+// one. It returns the error of the last call if every attempt fails.
+//
+// If ctx is done before an attempt or during a wait, Retry returns
+// context.Cause(ctx) immediately, so a signal that arrives while waiting is
+// reported as such, for example as a *SignalError if ctx was returned by
+// NotifyContextCause, rather than as the error of the last attempt.
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func(ctx context.Context) error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			t := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return context.Cause(ctx)
+			case <-t.C:
+			}
+			backoff *= 2
+		}
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
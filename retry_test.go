@@ -0,0 +1,58 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var errFlaky = errors.New("flaky")
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), 3, time.Millisecond, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errFlaky
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Retry = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	calls = 0
+	err = Retry(context.Background(), 2, time.Millisecond, func(ctx context.Context) error {
+		calls++
+		return errFlaky
+	})
+	if err != errFlaky || calls != 2 {
+		t.Errorf("Retry = %v after %d calls, want %v after 2", err, calls, errFlaky)
+	}
+}
+
+func TestRetrySignalDuringBackoff(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option())
+	defer stop()
+
+	calls := 0
+	start := time.Now()
+	err := Retry(c, 3, time.Minute, func(ctx context.Context) error {
+		calls++
+		time.AfterFunc(10*time.Millisecond, func() { n.send(syscall.SIGTERM) })
+		return errFlaky
+	})
+	if !errors.Is(err, &SignalError{Signal: syscall.SIGTERM}) {
+		t.Errorf("Retry = %v, want the SIGTERM cause", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn was called %d times, want 1", calls)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("Retry returned after %v, want it to return during the backoff", d)
+	}
+}
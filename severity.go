@@ -0,0 +1,83 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// SeverityLevel tells how urgently a canceled context asks its users to shut
+// down. Downstream code can use it to choose, for example, how long to drain
+// in-flight work.
+type SeverityLevel int
+
+const (
+	// NoSeverity is reported for contexts that were not canceled by a signal.
+	NoSeverity SeverityLevel = iota
+
+	// Graceful asks for an orderly shutdown.
+	Graceful
+
+	// Abortive asks for the shutdown to finish as soon as possible.
+	Abortive
+)
+
+func (s SeverityLevel) String() string {
+	switch s {
+	case NoSeverity:
+		return "none"
+	case Graceful:
+		return "graceful"
+	case Abortive:
+		return "abortive"
+	}
+	return "unknown"
+}
+
+// WithGracefulSignals marks the given signals as requesting a Graceful
+// shutdown.
+func WithGracefulSignals(signals ...os.Signal) Option {
+	return func(o *options) {
+		for _, sig := range signals {
+			o.setSeverity(sig, Graceful)
+		}
+	}
+}
+
+// WithAbortiveSignals marks the given signals as requesting an Abortive
+// shutdown.
+func WithAbortiveSignals(signals ...os.Signal) Option {
+	return func(o *options) {
+		for _, sig := range signals {
+			o.setSeverity(sig, Abortive)
+		}
+	}
+}
+
+// Severity returns the severity of the signal that canceled ctx. It returns
+// NoSeverity if ctx was not created by this package, is not done yet, or was
+// canceled by its parent or its stop function.
+func Severity(ctx context.Context) SeverityLevel {
+	sig, ok := Signal(ctx)
+	if !ok {
+		return NoSeverity
+	}
+	c, _ := fromContext(ctx)
+	return c.opts.severityOf(sig)
+}
+
+func (o *options) setSeverity(sig os.Signal, s SeverityLevel) {
+	if o.severity == nil {
+		o.severity = make(map[os.Signal]SeverityLevel)
+	}
+	o.severity[sig] = s
+}
+
+func (o *options) severityOf(sig os.Signal) SeverityLevel {
+	if s, ok := o.severity[sig]; ok {
+		return s
+	}
+	if s, ok := defaultSeverity[sig]; ok {
+		return s
+	}
+	return Graceful
+}
@@ -0,0 +1,14 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris,!windows
+
+package sigctx
+
+import "os"
+
+// defaultSeverity maps signals to their severity unless overridden with
+// WithGracefulSignals or WithAbortiveSignals. Signals that are not listed
+// here are treated as Graceful. os.Interrupt is the only signal these
+// platforms have in common.
+var defaultSeverity = map[os.Signal]SeverityLevel{
+	os.Interrupt: Graceful,
+}
@@ -0,0 +1,54 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  syscall.Signal
+		opts []Option
+		want SeverityLevel
+	}{
+		{"SIGTERM", syscall.SIGTERM, nil, Graceful},
+		{"SIGQUIT", syscall.SIGQUIT, nil, Abortive},
+		{"SIGUSR1", syscall.SIGUSR1, nil, Graceful},
+		{"WithAbortiveSignals", syscall.SIGUSR1, []Option{WithAbortiveSignals(syscall.SIGUSR1)}, Abortive},
+		{"WithGracefulSignals", syscall.SIGQUIT, []Option{WithGracefulSignals(syscall.SIGQUIT)}, Graceful},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, stop := New(context.Background(), []os.Signal{tt.sig}, tt.opts...)
+			defer stop()
+
+			if got := Severity(c); got != NoSeverity {
+				t.Errorf("Severity before signal = %v, want %v", got, NoSeverity)
+			}
+			syscall.Kill(syscall.Getpid(), tt.sig)
+			select {
+			case <-c.Done():
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for context to be done after %v", tt.sig)
+			}
+			if got := Severity(c); got != tt.want {
+				t.Errorf("Severity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityStop(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT})
+	stop()
+	if got := Severity(c); got != NoSeverity {
+		t.Errorf("Severity = %v, want %v", got, NoSeverity)
+	}
+}
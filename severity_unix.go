@@ -0,0 +1,19 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultSeverity maps signals to their severity unless overridden with
+// WithGracefulSignals or WithAbortiveSignals. Signals that are not listed
+// here are treated as Graceful.
+var defaultSeverity = map[os.Signal]SeverityLevel{
+	syscall.SIGINT:  Graceful,
+	syscall.SIGTERM: Graceful,
+	syscall.SIGQUIT: Abortive,
+	syscall.SIGABRT: Abortive,
+}
@@ -0,0 +1,58 @@
+package sigctx
+
+import (
+	"context"
+	"time"
+)
+
+// WithPropagatedValues lists the keys whose values ShutdownContext copies
+// from the canceled context, such as tracing baggage or request IDs.
+func WithPropagatedValues(keys ...any) Option {
+	return func(o *options) {
+		o.propagatedKeys = append(o.propagatedKeys, keys...)
+	}
+}
+
+// ShutdownContext returns a context for the work that has to run after ctx
+// is done, such as draining connections or flushing buffers. The returned
+// context is not derived from ctx, so it is not canceled with it; it is done
+// when timeout elapses or cancel is called.
+//
+// If ctx is, or is derived from, a context created by this package, the
+// values of ctx under the keys given to WithPropagatedValues are copied to
+// the returned context.
+func ShutdownContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(propagatedValues(ctx), timeout)
+}
+
+// propagatedValues returns a background context holding the values of ctx
+// under the keys given to WithPropagatedValues.
+func propagatedValues(ctx context.Context) context.Context {
+	sctx := context.Background()
+	if c, ok := fromContext(ctx); ok {
+		for _, key := range c.opts.propagatedKeys {
+			if v := ctx.Value(key); v != nil {
+				sctx = context.WithValue(sctx, key, v)
+			}
+		}
+	}
+	return sctx
+}
+
+// DefaultDrainTimeout is the timeout of the drain contexts given to
+// shutdown callbacks, unless set with WithDrainTimeout.
+const DefaultDrainTimeout = 30 * time.Second
+
+// WithDrainTimeout sets the timeout of the drain contexts given to shutdown
+// callbacks such as those registered with OnShutdownPriority.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = d
+	}
+}
+
+// drainContext returns a context for shutdown callbacks, as returned by
+// ShutdownContext with the drain timeout of c.
+func (c *signalCtx) drainContext() (context.Context, context.CancelFunc) {
+	return ShutdownContext(c, c.opts.drainTimeout)
+}
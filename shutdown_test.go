@@ -0,0 +1,48 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+type requestIDKey struct{}
+
+type baggageKey struct{}
+
+func TestShutdownContext(t *testing.T) {
+	parent := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	c, stop := New(parent, []os.Signal{os.Interrupt}, WithPropagatedValues(requestIDKey{}, baggageKey{}))
+	ctx := context.WithValue(c, baggageKey{}, "baggage")
+	stop()
+
+	sctx, cancel := ShutdownContext(ctx, time.Minute)
+	defer cancel()
+
+	if err := sctx.Err(); err != nil {
+		t.Errorf("sctx.Err() = %v, want nil", err)
+	}
+	if _, ok := sctx.Deadline(); !ok {
+		t.Errorf("expected shutdown context to have a deadline")
+	}
+	if got := sctx.Value(requestIDKey{}); got != "req-1" {
+		t.Errorf("sctx.Value(requestIDKey{}) = %v, want %q", got, "req-1")
+	}
+	if got := sctx.Value(baggageKey{}); got != "baggage" {
+		t.Errorf("sctx.Value(baggageKey{}) = %v, want %q", got, "baggage")
+	}
+}
+
+func TestShutdownContextNotPropagated(t *testing.T) {
+	parent := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	c, stop := New(parent, []os.Signal{os.Interrupt})
+	stop()
+
+	sctx, cancel := ShutdownContext(c, time.Minute)
+	defer cancel()
+
+	if got := sctx.Value(requestIDKey{}); got != nil {
+		t.Errorf("sctx.Value(requestIDKey{}) = %v, want nil", got)
+	}
+}
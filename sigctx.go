@@ -1,6 +1,6 @@
 // Copyright 2012 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
-// license that can be found in the LICENSE file.package sigctx
+// license that can be found in the LICENSE file.
 
 package sigctx
 
@@ -8,6 +8,11 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // NotifyContext returns a copy of the parent context that is marked done
@@ -24,62 +29,446 @@ import (
 //
 // The stop function releases resources associated with it, so code should
 // call stop as soon as the operations running in this Context complete and
-// signals no longer need to be diverted to the context.
+// signals no longer need to be diverted to the context. If the parent is
+// already done, the signals are not diverted at all.
+//
+// Signal contexts may be nested. Each registers its own channel, so a signal
+// listed by an inner context and its parent reaches both; whichever cancels
+// the inner context first, a context returned by NotifyContextCause reports
+// a *SignalError for it through context.Cause. The stop function of the
+// inner context only unregisters its own channel: the signals stay diverted
+// to the parent, and only regain their default behavior once no context
+// lists them.
+//
+// On Windows, only two signals are delivered: os.Interrupt, when the user
+// presses Ctrl+C or Ctrl+Break, and syscall.SIGTERM, when the console is
+// closed, the user logs off or the system shuts down.
 func NotifyContext(parent context.Context, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
-	ctx, cancel := context.WithCancel(parent)
+	return newSignalCtx(parent, signals, nil)
+}
+
+// New is like NotifyContext but accepts options that customize the behavior
+// of the returned context. Like NotifyContextCause, once a signal cancels
+// the returned context, context.Cause returns a *SignalError for it.
+func New(parent context.Context, signals []os.Signal, opts ...Option) (ctx context.Context, stop context.CancelFunc) {
+	return newSignalCtx(parent, signals, append(opts, withSignalCause))
+}
+
+func newSignalCtx(parent context.Context, signals []os.Signal, opts []Option) (*signalCtx, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
 	c := &signalCtx{
-		Context: ctx,
-		cancel:  cancel,
-		signals: signals,
-	}
-	c.ch = make(chan os.Signal, 1)
-	signal.Notify(c.ch, c.signals...)
-	if ctx.Err() == nil {
-		go func() {
-			select {
-			case <-c.ch:
-				c.cancel()
-			case <-c.Done():
-			}
-		}()
+		Context:     ctx,
+		parent:      parent,
+		cancelCause: cancel,
+		opts:        newOptions(opts),
+	}
+	// Copy the signals, so that the caller may reuse the backing array of
+	// its slice. Most contexts listen to one or two signals, which fit in
+	// signalsBuf.
+	c.signals = append(c.signalsBuf[:0:len(c.signalsBuf)], signals...)
+	c.allSignals = len(signals) == 0
+	c.pooled = c.opts.bufferSize == 1 && c.opts.overflow == nil && !c.opts.customNotifier
+	if c.pooled {
+		c.ch = chanPool.Get().(chan os.Signal)
+	} else {
+		c.ch = make(chan os.Signal, c.opts.bufferSize)
+	}
+	c.in = c.ch
+	if c.opts.overflow != nil {
+		c.in = make(chan os.Signal, cap(c.ch))
+		c.opts.watchers = append(c.opts.watchers, func(c *signalCtx) {
+			c.opts.overflow.forward(c.in, c.ch, c.Done())
+		})
+	}
+	if ctx.Err() == nil && c.opts.cancelAtCreation == nil {
+		// Only divert the signals if the parent is not already done, so that
+		// they keep their behavior even if stop is never called.
+		c.opts.notify(c.in, c.signals...)
+		c.diverted = true
+		for _, setup := range c.opts.setups {
+			setup(c)
+		}
+		c.wg.Add(1 + len(c.opts.watchers))
+		c.listener.ready.Add(1)
+		go c.watch()
+		for _, w := range c.opts.watchers {
+			go func(w func(*signalCtx)) {
+				defer c.wg.Done()
+				w(c)
+			}(w)
+		}
+	} else {
+		if ctx.Err() != nil {
+			c.setReason(ReasonParent)
+		} else {
+			c.opts.cancelAtCreation(c)
+		}
+		c.settled.Store(true)
+		if c.opts.countdown != nil {
+			close(c.opts.countdown)
+		}
 	}
 	return c, c.stop
 }
 
+// chanPool holds signal channels of capacity one that are no longer
+// registered, to spare an allocation to programs that create many short-lived
+// contexts.
+var chanPool = sync.Pool{
+	New: func() any { return make(chan os.Signal, 1) },
+}
+
 type signalCtx struct {
 	context.Context
 
-	cancel  context.CancelFunc
-	signals []os.Signal
-	ch      chan os.Signal
+	parent      context.Context
+	cancelCause context.CancelCauseFunc
+	signals     []os.Signal // guarded by mu
+	signalsBuf  [2]os.Signal
+	ch          chan os.Signal
+	opts        options
+
+	// pooled is set if ch comes from chanPool, to which stop returns it.
+	pooled bool
+
+	// allSignals is set if the context was given no signals, and so
+	// listens to all of them.
+	allSignals bool
+
+	// diverted is set if the signals were registered, which they are
+	// unless the parent was done at construction or the context was
+	// imported done.
+	diverted bool
+
+	pool *workerPool // set by NotifyContextPool
+
+	listener listenerInfo
+
+	// in is the channel registered with the notifier. It is ch, unless an
+	// overflow policy forwards signals from in to ch.
+	in chan os.Signal
+
+	progress atomic.Pointer[progress]
+
+	impatient atomic.Int32
+	delivered atomic.Int32
+
+	exitRequested atomic.Bool
+
+	// reason holds the Reason of the cancellation. It may be raised to a
+	// higher priority reason until settled is set, when the goroutine
+	// watching ch returns.
+	reason  atomic.Int32
+	settled atomic.Bool
+
+	// signalCause and stopCause are the causes of ReasonSignal and
+	// ReasonStop, as resolved by reasonCause. They are set before the
+	// reason is raised.
+	signalCause atomic.Pointer[error]
+	stopCause   atomic.Pointer[error]
+
+	// canceledAt is the time, in Unix nanoseconds, at which the context was
+	// canceled, or noticed to be.
+	canceledAt atomic.Int64
+
+	stopOnce sync.Once
+
+	// wg tracks the goroutine watching ch and the watchers added by options.
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	stopped  bool
+	received os.Signal // the signal that canceled the context, if any
+	events   eventSubscribers
+	canceled bool // whether the Canceled event was published
+	tracked  map[*tracked]struct{}
+	hooks    []shutdownHook
+	hooksRan bool
+	phases   *phases
+	causes   []error       // the causes of the triggers that fired, see AllCauses
+	stopping chan struct{} // closed by stop, see stoppingChan
+}
+
+// watch waits for a signal that cancels the context or for the context to
+// be done, whichever happens first, then counts the signals that keep
+// arriving until stop is called.
+func (c *signalCtx) watch() {
+	defer c.wg.Done()
+	defer c.settled.Store(true)
+	c.listener.started = time.Now()
+	if c.opts.listenerID {
+		c.listener.goid = goroutineID()
+	}
+	c.listener.ready.Done()
+	var expired <-chan time.Time
+	if c.opts.maxLifetime > 0 {
+		t := time.NewTimer(c.opts.maxLifetime)
+		defer t.Stop()
+		expired = t.C
+	}
+	for {
+		select {
+		case <-expired:
+			c.opts.stopNotify(c.in)
+			return
+		case sig := <-c.ch:
+			c.publish(Event{Type: Received, Signal: sig, Time: time.Now()})
+			if !c.opts.allow(sig) {
+				continue
+			}
+			for _, hook := range c.opts.beforeCancel {
+				hook(c, sig)
+			}
+			c.cancelSignal(sig)
+			c.checkParent()
+			c.settled.Store(true)
+			c.countImpatient()
+			return
+		case <-c.Done():
+			c.markCanceled()
+			// A signal that arrived together with the cancellation may
+			// still take precedence over it.
+			select {
+			case sig := <-c.ch:
+				c.publish(Event{Type: Received, Signal: sig, Time: time.Now()})
+				if c.opts.allow(sig) {
+					c.cancelSignal(sig)
+				}
+			default:
+			}
+			c.checkParent()
+			if !c.isStopped() {
+				c.publishCanceled()
+			}
+			c.settled.Store(true)
+			c.countImpatient()
+			return
+		}
+	}
+}
+
+// startGrace starts the grace period of c, if it has one.
+func (c *signalCtx) startGrace() {
+	if c.opts.grace != nil {
+		c.opts.grace.start()
+	}
+}
+
+// checkParent records ReasonParent if the parent of c is done.
+func (c *signalCtx) checkParent() {
+	if c.parent.Err() != nil {
+		c.setReason(ReasonParent)
+		c.recordCause(context.Cause(c.parent))
+	}
+}
+
+// cancel cancels c with cause, which is context.Canceled if nil.
+func (c *signalCtx) cancel(cause error) {
+	c.recordCause(cause)
+	c.cancelCause(cause)
+}
+
+// cancelSignal cancels c because sig was received. It reports whether c was
+// still live. The signal is recorded before Done is closed, or even if c was
+// already done, along with its cause, if ReasonSignal outranks the reason it
+// was canceled for.
+func (c *signalCtx) cancelSignal(sig os.Signal) bool {
+	var cause error = context.Canceled
+	if c.opts.signalCause {
+		cause = &SignalError{Signal: sig}
+	}
+	c.mu.Lock()
+	if c.received == nil {
+		c.signalCause.Store(&cause)
+	}
+	raised := c.setReason(ReasonSignal)
+	if c.Err() != nil {
+		if raised {
+			c.received = sig
+			c.startGrace()
+		}
+		c.mu.Unlock()
+		if raised {
+			// The signal was pending when c was canceled, for example by
+			// its parent right after the signals were registered, and still
+			// counts as a cause.
+			c.recordCause(cause)
+		}
+		return false
+	}
+	if c.received != nil {
+		// Another signal is canceling c.
+		c.mu.Unlock()
+		return false
+	}
+	c.received = sig
+	c.startGrace()
+	c.markCanceled()
+	c.mu.Unlock()
+	cancel := func() { c.cancel(cause) }
+	if c.opts.interceptor != nil {
+		cancel = c.opts.interceptor(cancel)
+	}
+	cancel()
+	c.publishCanceled()
+	if sink := c.opts.auditSink; sink != nil {
+		sink(AuditEntry{Signal: sig, SenderPID: unknownSender, Time: time.Now()})
+	}
+	runGlobalHooks(sig)
+	return true
+}
+
+// stoppingChan returns a channel that is closed once stop is called, for
+// goroutines that keep running after c is done.
+func (c *signalCtx) stoppingChan() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopping == nil {
+		if c.stopped {
+			return closedChan
+		}
+		c.stopping = make(chan struct{})
+	}
+	return c.stopping
+}
+
+// closedChan is a closed channel.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+func (c *signalCtx) isStopped() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopped
 }
 
 func (c *signalCtx) stop() {
-	c.cancel()
-	signal.Stop(c.ch)
+	var cause error
+	if c.opts.stopTrace && !c.isStopped() {
+		cause = newStopTraceError()
+	}
+	c.stopOnce.Do(func() { c.teardown(cause) })
+}
+
+// teardown implements stop, canceling c with cause. It runs once, and
+// concurrent calls to stop return once it has.
+func (c *signalCtx) teardown(cause error) {
+	c.mu.Lock()
+	c.stopped = true
+	if c.stopping != nil {
+		close(c.stopping)
+	}
+	// AddSignal and RemoveSignal no longer change the signals.
+	signals := c.signals
+	c.mu.Unlock()
+	if cause == nil {
+		cause = context.Canceled
+	}
+	c.stopCause.Store(&cause)
+	c.setReason(ReasonStop)
+	// Unregister before canceling, so that any signal delivered before
+	// stop is seen by watch when it drains ch.
+	c.opts.stopNotify(c.in)
+	if c.diverted && c.opts.reset {
+		signal.Reset(signals...)
+	}
+	for _, fn := range c.opts.onStop {
+		fn()
+	}
+	c.markCanceled()
+	if c.Err() == nil {
+		c.cancel(cause)
+	} else {
+		// Releasing a context that is already done is not a trigger.
+		c.cancelCause(cause)
+	}
+	c.wg.Wait()
+	if c.pooled {
+		// The signal package no longer sends on ch, and watch returned.
+		select {
+		case <-c.ch:
+		default:
+		}
+		chanPool.Put(c.ch)
+	}
+	c.closeEvents(Event{Type: Stopped, Time: time.Now()})
+	if sink := c.opts.durationSink; sink != nil {
+		sink(time.Since(time.Unix(0, c.canceledAt.Load())))
+	}
+}
+
+// markCanceled records the current time as the time of cancellation, unless
+// one was already recorded.
+func (c *signalCtx) markCanceled() {
+	c.canceledAt.CompareAndSwap(0, time.Now().UnixNano())
+}
+
+// Value returns c for signalCtxKey so that the package level helpers can
+// find c through contexts derived from it.
+func (c *signalCtx) Value(key any) any {
+	switch key {
+	case signalCtxKey{}:
+		return c
+	case SignalKey{}:
+		if sig, ok := Signal(c); ok {
+			return sig
+		}
+	}
+	return c.Context.Value(key)
+}
+
+type signalCtxKey struct{}
+
+// fromContext returns the signal context that ctx is, or is derived from.
+func fromContext(ctx context.Context) (*signalCtx, bool) {
+	c, ok := ctx.Value(signalCtxKey{}).(*signalCtx)
+	return c, ok
 }
 
 type stringer interface {
 	String() string
 }
 
+// contextName describes ctx like the context package does: with its String
+// method if it has one, or with the name of its type otherwise.
+func contextName(ctx context.Context) string {
+	if s, ok := ctx.(stringer); ok {
+		return s.String()
+	}
+	return reflect.TypeOf(ctx).String()
+}
+
+// String describes c in the same way the context package describes its own
+// contexts, so that contexts derived from c read naturally, for example
+// signal.NotifyContext(context.Background, [interrupt]).WithValue(k, v).
 func (c *signalCtx) String() string {
-	var buf []byte
-	// We know that the type of c.Context is context.cancelCtx, and we know that the
-	// String method of cancelCtx returns a string that ends with ".WithCancel".
-	name := c.Context.(stringer).String()
-	name = name[:len(name)-len(".WithCancel")]
-	buf = append(buf, "signal.NotifyContext("+name...)
-	if len(c.signals) != 0 {
-		buf = append(buf, ", ["...)
-		for i, s := range c.signals {
-			buf = append(buf, s.String()...)
-			if i != len(c.signals)-1 {
-				buf = append(buf, ' ')
+	var b strings.Builder
+	// The type of c.Context is normally context.cancelCtx, whose String method
+	// returns a string that ends with ".WithCancel". Only trim the suffix when
+	// it is actually there.
+	name := strings.TrimSuffix(contextName(c.Context), ".WithCancel")
+	b.WriteString("signal.NotifyContext(")
+	b.WriteString(name)
+	if c.opts.all {
+		b.WriteString(", [all]")
+	} else if !c.allSignals {
+		signals := c.currentSignals()
+		b.WriteString(", [")
+		for i, s := range signals {
+			b.WriteString(s.String())
+			if i != len(signals)-1 {
+				b.WriteByte(' ')
 			}
 		}
-		buf = append(buf, ']')
+		b.WriteByte(']')
+	}
+	b.WriteByte(')')
+	if c.opts.callerTag != "" {
+		b.WriteString(" created at ")
+		b.WriteString(c.opts.callerTag)
 	}
-	buf = append(buf, ')')
-	return string(buf)
+	return b.String()
 }
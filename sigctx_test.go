@@ -2,15 +2,19 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// +build aix darwin dragonfly freebsd linux netbsd openbsd solarispackage sigctx
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
 
 package sigctx
 
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/signal"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -35,6 +39,29 @@ func TestNotifyContext(t *testing.T) {
 	}
 }
 
+// withIgnored ignores sigs until the end of the test, so that a test can
+// observe the disposition of signals, including after a stop function
+// restored their default behavior, without risking to terminate the test
+// binary. Signals that were not ignored before are reset afterwards.
+func withIgnored(t *testing.T, sigs ...os.Signal) {
+	t.Helper()
+	var reset []os.Signal
+	for _, sig := range sigs {
+		if !signal.Ignored(sig) {
+			reset = append(reset, sig)
+		}
+	}
+	signal.Ignore(sigs...)
+	if len(reset) > 0 {
+		t.Cleanup(func() { signal.Reset(reset...) })
+	}
+	for _, sig := range sigs {
+		if !signal.Ignored(sig) {
+			t.Fatalf("expected %v to be ignored when explicitly ignoring it.", sig)
+		}
+	}
+}
+
 func TestNotifyContextStop(t *testing.T) {
 	signal.Ignore(syscall.SIGHUP)
 	if !signal.Ignored(syscall.SIGHUP) {
@@ -165,6 +192,32 @@ func TestNotifyContextSimultaneousStop(t *testing.T) {
 	}
 }
 
+func TestNotifyContextSimultaneousStopOnce(t *testing.T) {
+	var stopNotifyCalls, sinkCalls atomic.Int32
+	notify := func(chan<- os.Signal, ...os.Signal) {}
+	stopNotify := func(chan<- os.Signal) { stopNotifyCalls.Add(1) }
+	_, stop := New(context.Background(), []os.Signal{syscall.SIGINT},
+		WithNotifier(notify, stopNotify),
+		WithDurationSink(func(time.Duration) { sinkCalls.Add(1) }))
+
+	var wg sync.WaitGroup
+	n := 10
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			stop()
+			if got := sinkCalls.Load(); got != 1 {
+				t.Errorf("stop returned after %d calls to the duration sink, want 1", got)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+	if got := stopNotifyCalls.Load(); got != 1 {
+		t.Errorf("signals unregistered %d times, want 1", got)
+	}
+}
+
 func TestNotifyContextStringer(t *testing.T) {
 	parent, cancelParent := context.WithCancel(context.Background())
 	defer cancelParent()
@@ -176,3 +229,136 @@ func TestNotifyContextStringer(t *testing.T) {
 		t.Errorf("c.String() = %q, want %q", got, want)
 	}
 }
+
+func TestNotifyContextStopRestoresDefault(t *testing.T) {
+	withIgnored(t, syscall.SIGINT)
+
+	_, stop := NotifyContext(context.Background(), syscall.SIGINT)
+	if signal.Ignored(syscall.SIGINT) {
+		t.Errorf("expected SIGINT to not be ignored while notifying.")
+	}
+
+	// stop restores the default behavior rather than the previous
+	// disposition, so SIGINT is no longer ignored, and would terminate the
+	// process if it arrived before withIgnored resets it.
+	stop()
+	if signal.Ignored(syscall.SIGINT) {
+		t.Errorf("expected SIGINT to not be ignored after stop.")
+	}
+}
+
+func TestNotifyContextReuse(t *testing.T) {
+	withIgnored(t, syscall.SIGUSR1)
+
+	for i := 0; i < 10; i++ {
+		c, stop := NotifyContext(context.Background(), syscall.SIGUSR1)
+		syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+		select {
+		case <-c.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("iteration %d: timed out waiting for context to be done after SIGUSR1", i)
+		}
+		if sig, ok := Signal(c); !ok || sig != syscall.SIGUSR1 {
+			t.Errorf("iteration %d: Signal(c) = %v, %v, want %v, true", i, sig, ok, syscall.SIGUSR1)
+		}
+		stop()
+	}
+}
+
+func TestNotifyContextStopDrainsChannel(t *testing.T) {
+	// With a parent that is already done, nothing reads the channel.
+	parent, cancelParent := context.WithCancel(context.Background())
+	cancelParent()
+	c, stop := newSignalCtx(parent, []os.Signal{syscall.SIGUSR1}, nil)
+	if !c.pooled {
+		t.Fatalf("expected the channel of a default context to be pooled")
+	}
+	// A signal left in the channel must not leak into another context.
+	c.ch <- syscall.SIGUSR1
+	stop()
+	stop()
+	if n := len(c.ch); n != 0 {
+		t.Errorf("pooled channel holds %d signals after stop, want 0", n)
+	}
+}
+
+func TestNotifyContextCause(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+	c, stop := NotifyContextCause(parent, syscall.SIGTERM)
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	if got := c.Err(); got != context.Canceled {
+		t.Errorf("c.Err() = %v, want %v", got, context.Canceled)
+	}
+	want := &SignalError{Signal: syscall.SIGTERM}
+	if got := context.Cause(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("context.Cause(c) = %v, want %v", got, want)
+	}
+}
+
+func TestNotifyContextPrematureCancelParentRegistration(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	cancelParent()
+	c, _ := NotifyContext(parent, syscall.SIGINT)
+	<-c.Done()
+
+	// stop is deliberately not called.
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch != nil {
+		t.Errorf("signals %v registered although the parent was already done", n.signals)
+	}
+}
+
+func TestNotifyContextNested(t *testing.T) {
+	outer, stopOuter := NotifyContextCause(context.Background(), syscall.SIGINT)
+	defer stopOuter()
+	inner, stopInner := NotifyContextCause(outer, syscall.SIGINT)
+	defer stopInner()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	for _, c := range []context.Context{outer, inner} {
+		select {
+		case <-c.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %v to be done after SIGINT", c)
+		}
+		if cause := context.Cause(c); !IsSignal(cause, syscall.SIGINT) {
+			t.Errorf("context.Cause(%v) = %v, want a *SignalError for %v", c, cause, syscall.SIGINT)
+		}
+	}
+}
+
+func TestNotifyContextNestedInnerStop(t *testing.T) {
+	outer, stopOuter := NotifyContext(context.Background(), syscall.SIGINT)
+	defer stopOuter()
+	inner, stopInner := NotifyContext(outer, syscall.SIGINT)
+	stopInner()
+	if inner.Err() == nil {
+		t.Fatalf("expected stop to cancel the inner context")
+	}
+
+	// The signal still reaches the outer context rather than killing the
+	// process.
+	syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	select {
+	case <-outer.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the outer context after the inner one was stopped")
+	}
+	if sig, ok := Signal(outer); !ok || sig != syscall.SIGINT {
+		t.Errorf("Signal(outer) = %v, %v, want %v, true", sig, ok, syscall.SIGINT)
+	}
+}
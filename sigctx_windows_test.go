@@ -0,0 +1,95 @@
+//go:build windows
+// +build windows
+
+package sigctx
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// childEnv makes the test binary run as the child of TestNotifyContextCtrlBreak.
+const childEnv = "SIGCTX_TEST_CTRL_BREAK_CHILD"
+
+// TestMain runs the child side of TestNotifyContextCtrlBreak when asked to,
+// and the tests otherwise.
+func TestMain(m *testing.M) {
+	if os.Getenv(childEnv) == "1" {
+		os.Exit(runCtrlBreakChild())
+	}
+	os.Exit(m.Run())
+}
+
+func runCtrlBreakChild() int {
+	c, stop := NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Println("ready")
+	select {
+	case <-c.Done():
+	case <-time.After(10 * time.Second):
+		fmt.Println("missed signal")
+		return 1
+	}
+	if sig, ok := Signal(c); !ok || sig != os.Interrupt {
+		fmt.Printf("Signal(c) = %v, %v\n", sig, ok)
+		return 1
+	}
+	return 0
+}
+
+// sendCtrlBreak sends a CTRL_BREAK_EVENT to the process group pid.
+func sendCtrlBreak(pid int) error {
+	proc := syscall.NewLazyDLL("kernel32.dll").NewProc("GenerateConsoleCtrlEvent")
+	if r, _, err := proc.Call(syscall.CTRL_BREAK_EVENT, uintptr(pid)); r == 0 {
+		return err
+	}
+	return nil
+}
+
+// TestNotifyContextCtrlBreak checks that a console control event, which the
+// Go runtime delivers as os.Interrupt, cancels the context. The event is
+// sent to a child process in its own process group, so that it does not
+// reach the test runner.
+func TestNotifyContextCtrlBreak(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), childEnv+"=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+	cmd.Stderr = os.Stderr
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+
+	lines := bufio.NewScanner(out)
+	if !lines.Scan() || lines.Text() != "ready" {
+		t.Fatalf("child did not get ready: %q, %v", lines.Text(), lines.Err())
+	}
+	if err := sendCtrlBreak(cmd.Process.Pid); err != nil {
+		t.Fatalf("GenerateConsoleCtrlEvent: %v", err)
+	}
+	for lines.Scan() {
+		t.Logf("child: %s", lines.Text())
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("child failed: %v", err)
+	}
+}
+
+func TestNotifyContextStringerWindows(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if want, got := "signal.NotifyContext(context.Background, [interrupt terminated])", fmt.Sprint(c); want != got {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// SignalKey is the context key under which a signal context holds the
+// signal that canceled it, so that code that only sees a context.Context,
+// such as logging middleware, can report it without importing this package
+// for anything but the key:
+//
+//	if sig, ok := ctx.Value(sigctx.SignalKey{}).(os.Signal); ok {
+//		logger.Info("shutting down", "signal", sig)
+//	}
+//
+// The value is that returned by Signal, or, when the context was not
+// canceled by a signal, the value of its parent for the key, which is nil
+// unless it is itself derived from a signal context canceled by a signal.
+type SignalKey struct{}
+
+// Signal returns the signal that canceled ctx, or false if ctx was not
+// created by this package, is not done yet, or was canceled by its parent or
+// its stop function, unless a signal arrived at the same time and won as
+// described in WithReasonPriority. ctx may also be a context derived from a
+// signal context.
+func Signal(ctx context.Context) (os.Signal, bool) {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.received, c.received != nil
+}
+
+// IsStopped reports whether the stop function of the signal context of ctx
+// was called, whether or not a signal or the parent canceled the context
+// first. It lets deferred cleanup tell a clean teardown from an
+// interruption. It returns false if ctx has no signal context.
+func IsStopped(ctx context.Context) bool {
+	c, ok := fromContext(ctx)
+	return ok && c.isStopped()
+}
@@ -0,0 +1,156 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSignalReceived(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT, syscall.SIGTERM}, n.option())
+	defer stop()
+
+	if sig, ok := Signal(c); ok {
+		t.Errorf("Signal(c) = %v, true before cancellation", sig)
+	}
+	// Read the signal as soon as Done is closed, which must not race with
+	// recording it.
+	got := make(chan os.Signal, 1)
+	go func() {
+		<-c.Done()
+		sig, _ := Signal(c)
+		got <- sig
+	}()
+	n.send(syscall.SIGTERM)
+	select {
+	case sig := <-got:
+		if sig != syscall.SIGTERM {
+			t.Errorf("Signal(c) = %v once done, want %v", sig, syscall.SIGTERM)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+
+	derived, cancel := context.WithCancel(c)
+	defer cancel()
+	if sig, ok := Signal(derived); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(derived) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+}
+
+func TestSignalParent(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	var n fakeNotifier
+	c, stop := New(parent, []os.Signal{syscall.SIGINT}, n.option())
+	defer stop()
+
+	cancelParent()
+	<-c.Done()
+	if sig, ok := Signal(c); ok {
+		t.Errorf("Signal(c) = %v, true after the parent was canceled", sig)
+	}
+}
+
+func TestSignalStop(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, n.option())
+	stop()
+	if sig, ok := Signal(c); ok {
+		t.Errorf("Signal(c) = %v, true after stop", sig)
+	}
+	if sig, ok := Signal(context.Background()); ok {
+		t.Errorf("Signal(context.Background()) = %v, true", sig)
+	}
+}
+
+func TestIsStopped(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		var n fakeNotifier
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, n.option())
+		defer stop()
+		n.send(syscall.SIGINT)
+		<-c.Done()
+		if IsStopped(c) {
+			t.Errorf("IsStopped(c) = true after a signal")
+		}
+		stop()
+		if !IsStopped(c) {
+			t.Errorf("IsStopped(c) = false after stop")
+		}
+	})
+	t.Run("parent", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		c, stop := New(parent, []os.Signal{syscall.SIGINT})
+		defer stop()
+		cancelParent()
+		<-c.Done()
+		if IsStopped(c) {
+			t.Errorf("IsStopped(c) = true after the parent was canceled")
+		}
+	})
+	t.Run("stop", func(t *testing.T) {
+		c, stop := New(context.Background(), []os.Signal{syscall.SIGINT})
+		if IsStopped(c) {
+			t.Errorf("IsStopped(c) = true before stop")
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				stop()
+				if !IsStopped(c) {
+					t.Errorf("IsStopped(c) = false after stop returned")
+				}
+			}()
+		}
+		wg.Wait()
+		derived, cancel := context.WithCancel(c)
+		defer cancel()
+		if !IsStopped(derived) {
+			t.Errorf("IsStopped(derived) = false after stop")
+		}
+	})
+	if IsStopped(context.Background()) {
+		t.Errorf("IsStopped(context.Background()) = true")
+	}
+}
+
+type testKey struct{}
+
+func TestSignalKey(t *testing.T) {
+	parent := context.WithValue(context.Background(), testKey{}, "parent value")
+	var n fakeNotifier
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, n.option())
+	defer stop()
+
+	if v := c.Value(SignalKey{}); v != nil {
+		t.Errorf("c.Value(SignalKey{}) = %v before cancellation, want nil", v)
+	}
+	if v := c.Value(testKey{}); v != "parent value" {
+		t.Errorf("c.Value(testKey{}) = %v, want the value of the parent", v)
+	}
+
+	n.send(syscall.SIGTERM)
+	<-c.Done()
+	derived, cancel := context.WithCancel(c)
+	defer cancel()
+	if sig, ok := derived.Value(SignalKey{}).(os.Signal); !ok || sig != syscall.SIGTERM {
+		t.Errorf("derived.Value(SignalKey{}) = %v, want %v", sig, syscall.SIGTERM)
+	}
+	if v := derived.Value(testKey{}); v != "parent value" {
+		t.Errorf("derived.Value(testKey{}) = %v, want the value of the parent", v)
+	}
+}
+
+func TestSignalKeyStop(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM})
+	stop()
+	if v := c.Value(SignalKey{}); v != nil {
+		t.Errorf("c.Value(SignalKey{}) = %v after stop, want nil", v)
+	}
+}
@@ -0,0 +1,56 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+)
+
+// NotifyContextFunc is like NotifyContext, but calls fn with the signal that
+// is about to cancel the context, as described for WithSignalFunc.
+func NotifyContextFunc(parent context.Context, fn func(os.Signal), signals ...os.Signal) (ctx context.Context, stop context.CancelFunc) {
+	return New(parent, signals, WithSignalFunc(fn))
+}
+
+// WithSignalFunc makes the context call fn with the signal that is about to
+// cancel it, on the goroutine watching the signals, before Done is closed,
+// so that fn can, for example, write a log line that is guaranteed to come
+// before any reaction to the cancellation. fn is not called when the context
+// is canceled by its parent or its stop function, nor for signals delivered
+// by Trigger.
+//
+// If fn panics, the panic is recovered and the context is canceled with a
+// *PanicError as its cause; the signals stay registered until stop is
+// called, as usual.
+func WithSignalFunc(fn func(os.Signal)) Option {
+	return func(o *options) {
+		o.beforeCancel = append(o.beforeCancel, func(c *signalCtx, sig os.Signal) {
+			defer c.recoverCallback()
+			fn(sig)
+		})
+	}
+}
+
+// recoverCallback recovers from a panic in a callback called before c is
+// canceled and cancels c with a *PanicError. It must be deferred directly.
+func (c *signalCtx) recoverCallback() {
+	if v := recover(); v != nil {
+		c.markCanceled()
+		c.cancel(&PanicError{Value: v, Stack: debug.Stack()})
+	}
+}
+
+// WithTraceCallback is like WithSignalFunc, but fn is also given the
+// context, which is not done yet, so that it can find the active tracing
+// span in it and record the signal on the span, for example with
+// span.AddEvent, before the cancellation ends it. As with WithSignalFunc, a
+// panic in fn is recovered and the context is canceled with a *PanicError as
+// its cause.
+func WithTraceCallback(fn func(ctx context.Context, sig os.Signal)) Option {
+	return func(o *options) {
+		o.beforeCancel = append(o.beforeCancel, func(c *signalCtx, sig os.Signal) {
+			defer c.recoverCallback()
+			fn(c, sig)
+		})
+	}
+}
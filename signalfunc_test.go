@@ -0,0 +1,123 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithSignalFunc(t *testing.T) {
+	var n fakeNotifier
+	var got os.Signal
+	var live bool
+	var c context.Context
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithSignalFunc(func(sig os.Signal) {
+		got = sig
+		live = c.Err() == nil
+	}))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	if got != syscall.SIGTERM {
+		t.Errorf("fn called with %v, want %v", got, syscall.SIGTERM)
+	}
+	if !live {
+		t.Errorf("fn called after Done was closed")
+	}
+}
+
+func TestWithSignalFuncNotCalled(t *testing.T) {
+	called := false
+	fn := WithSignalFunc(func(os.Signal) { called = true })
+
+	parent, cancel := context.WithCancel(context.Background())
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, fn)
+	cancel()
+	<-c.Done()
+	stop()
+
+	_, stop = New(context.Background(), []os.Signal{syscall.SIGTERM}, fn)
+	stop()
+	if called {
+		t.Errorf("fn called without a signal")
+	}
+}
+
+func TestWithSignalFuncPanic(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithSignalFunc(func(os.Signal) {
+		panic(errBoom)
+	}))
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after a panic in fn")
+	}
+	if cause := context.Cause(c); !errors.Is(cause, ErrPanic) || !errors.Is(cause, errBoom) {
+		t.Errorf("context.Cause(c) = %v, want a panic cause wrapping %v", cause, errBoom)
+	}
+	if n.stopped {
+		t.Errorf("signals unregistered before stop")
+	}
+	stop()
+	if !n.stopped {
+		t.Errorf("expected stop to unregister the signals")
+	}
+}
+
+type spanKey struct{}
+
+func TestWithTraceCallback(t *testing.T) {
+	type call struct {
+		span any
+		err  error
+		sig  os.Signal
+	}
+	calls := make(chan call, 1)
+	parent := context.WithValue(context.Background(), spanKey{}, "span")
+	var n fakeNotifier
+	c, stop := New(parent, []os.Signal{syscall.SIGTERM}, n.option(), WithTraceCallback(func(ctx context.Context, sig os.Signal) {
+		calls <- call{span: ctx.Value(spanKey{}), err: ctx.Err(), sig: sig}
+	}))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case got := <-calls:
+		want := call{span: "span", sig: syscall.SIGTERM}
+		if got != want {
+			t.Errorf("callback got %+v, want %+v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the trace callback")
+	}
+	<-c.Done()
+}
+
+func TestWithTraceCallbackPanic(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithTraceCallback(func(context.Context, os.Signal) {
+		panic(errBoom)
+	}))
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after a panic in fn")
+	}
+	if cause := context.Cause(c); !errors.Is(cause, ErrPanic) || !errors.Is(cause, errBoom) {
+		t.Errorf("context.Cause(c) = %v, want a panic cause wrapping %v", cause, errBoom)
+	}
+}
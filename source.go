@@ -0,0 +1,39 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// newNotifyContext is like NotifyContext, but takes the signals from src
+// instead of the signal package, so that tests can drive the cancellation by
+// sending on src without sending real signals. Signals that are not listed
+// are ignored, unless no signal is listed.
+func newNotifyContext(parent context.Context, src <-chan os.Signal, signals ...os.Signal) (*signalCtx, context.CancelFunc) {
+	var once sync.Once
+	done := make(chan struct{})
+	notify := func(c chan<- os.Signal, sig ...os.Signal) {
+		go func() {
+			for {
+				select {
+				case s := <-src:
+					if !wants(sig, s) {
+						continue
+					}
+					select {
+					case c <- s:
+					case <-done:
+						return
+					}
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	stop := func(chan<- os.Signal) {
+		once.Do(func() { close(done) })
+	}
+	return newSignalCtx(parent, signals, []Option{WithNotifier(notify, stop)})
+}
@@ -0,0 +1,97 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNewNotifyContext(t *testing.T) {
+	src := make(chan os.Signal)
+	c, stop := newNotifyContext(context.Background(), src, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	src <- syscall.SIGKILL
+	if err := c.Err(); err != nil {
+		t.Fatalf("c.Err() = %v after an unlisted signal, want nil", err)
+	}
+	src <- syscall.SIGTERM
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+
+	stop()
+	select {
+	case src <- syscall.SIGINT:
+		t.Errorf("signal taken from the source after stop")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestNotifyContextSeam(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	c, stop := NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after SIGTERM")
+	}
+	stop()
+	if !n.stopped {
+		t.Errorf("expected stop to unregister through stopFunc")
+	}
+}
+
+// TestNotifyContextPendingSignal covers a signal that is already buffered
+// when the goroutine watching the signals starts, while the parent was
+// canceled in between: the signal still counts as a cause of the
+// cancellation.
+func TestNotifyContextPendingSignal(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	defer cancelParent()
+	src := make(chan os.Signal, 1)
+	src <- syscall.SIGTERM
+	notify := func(c chan<- os.Signal, _ ...os.Signal) {
+		c <- <-src
+		cancelParent()
+	}
+	c, stop := newSignalCtx(parent, []os.Signal{syscall.SIGTERM}, []Option{WithNotifier(notify, func(chan<- os.Signal) {}), withSignalCause})
+	defer stop()
+
+	for deadline := time.Now().Add(time.Second); !c.settled.Load(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the cancellation to settle")
+		}
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+	if r := CancelReason(c); r != ReasonSignal {
+		t.Errorf("CancelReason(c) = %v, want %v", r, ReasonSignal)
+	}
+	var found bool
+	for _, err := range AllCauses(c) {
+		var serr *SignalError
+		if errors.As(err, &serr) && serr.Signal == syscall.SIGTERM {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("AllCauses(c) = %v, want a *SignalError for %v", AllCauses(c), syscall.SIGTERM)
+	}
+}
@@ -0,0 +1,88 @@
+package sigctx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// state is the serialized form of a signal context.
+type state struct {
+	Signals []string `json:"signals"`
+	Reason  string   `json:"reason,omitempty"`
+	Signal  string   `json:"signal,omitempty"`
+	Cause   string   `json:"cause,omitempty"`
+}
+
+// ExportState serializes the signals of the signal context of ctx and, if it
+// is done, why, so that ImportState can rebuild an equivalent context in
+// another process, for example a successor taking over during a hot restart.
+// It returns ErrNotSignalContext if ctx has no signal context.
+func ExportState(ctx context.Context) ([]byte, error) {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return nil, ErrNotSignalContext
+	}
+	signals := c.currentSignals()
+	s := state{Signals: make([]string, len(signals))}
+	for i, sig := range signals {
+		s.Signals[i] = encodeSignal(sig)
+	}
+	if c.Err() != nil {
+		s.Reason = CancelReason(c).String()
+		if sig, ok := Signal(c); ok {
+			s.Signal = encodeSignal(sig)
+		}
+		s.Cause = EncodeCause(Cause(c))
+	}
+	return json.Marshal(s)
+}
+
+// ImportState returns a signal context derived from parent that listens to
+// the signals recorded in data by ExportState. If the exported context was
+// done, the returned context is already canceled with the same cause, and
+// CancelReason and Signal report the same values; its signals are then not
+// diverted, as for a context whose parent is done. Signals are decoded by
+// number, so data should come from the same platform.
+func ImportState(parent context.Context, data []byte) (context.Context, context.CancelFunc, error) {
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, nil, fmt.Errorf("sigctx: invalid state: %w", err)
+	}
+	reason, ok := parseReason(s.Reason)
+	if !ok && s.Reason != "" {
+		return nil, nil, fmt.Errorf("sigctx: invalid state: unknown reason %q", s.Reason)
+	}
+	signals := make([]os.Signal, len(s.Signals))
+	for i, sig := range s.Signals {
+		signals[i] = decodeSignal(sig)
+	}
+
+	cause := DecodeCause(s.Cause)
+	sig, ok := AnySignal(cause)
+	var opts []Option
+	if ok {
+		opts = append(opts, withSignalCause)
+	}
+	if s.Signal != "" {
+		sig, ok = decodeSignal(s.Signal), true
+	}
+	if s.Reason != "" {
+		// The context is dead from birth: cancel it instead of diverting the
+		// signals.
+		opts = append(opts, func(o *options) {
+			o.cancelAtCreation = func(c *signalCtx) {
+				if ok && reason == ReasonSignal {
+					c.cancelSignal(sig)
+					return
+				}
+				c.setReason(reason)
+				c.markCanceled()
+				c.cancel(cause)
+			}
+		})
+	}
+	c, stop := newSignalCtx(parent, signals, opts)
+	return c, stop, nil
+}
@@ -0,0 +1,135 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestExportImportState(t *testing.T) {
+	c, stop := NotifyContextCause(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	Trigger(c, syscall.SIGTERM)
+
+	data, err := ExportState(c)
+	if err != nil {
+		t.Fatalf("ExportState = %v", err)
+	}
+	ic, istop, err := ImportState(context.Background(), data)
+	if err != nil {
+		t.Fatalf("ImportState(%s) = %v", data, err)
+	}
+	defer istop()
+
+	select {
+	case <-ic.Done():
+	default:
+		t.Fatalf("imported context is not done")
+	}
+	if cause := context.Cause(ic); !IsSignal(cause, syscall.SIGTERM) {
+		t.Errorf("context.Cause(imported) = %v, want SIGTERM", cause)
+	}
+	if sig, ok := Signal(ic); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(imported) = %v, %v, want SIGTERM, true", sig, ok)
+	}
+	if got := CancelReason(ic); got != ReasonSignal {
+		t.Errorf("CancelReason(imported) = %v, want %v", got, ReasonSignal)
+	}
+	if want, got := fmt.Sprint(c), fmt.Sprint(ic); want != got {
+		t.Errorf("imported context is %q, want %q", got, want)
+	}
+}
+
+func TestExportImportStateSignal(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	Trigger(c, syscall.SIGTERM)
+
+	data, err := ExportState(c)
+	if err != nil {
+		t.Fatalf("ExportState = %v", err)
+	}
+	ic, istop, err := ImportState(context.Background(), data)
+	if err != nil {
+		t.Fatalf("ImportState(%s) = %v", data, err)
+	}
+	defer istop()
+	if cause := context.Cause(ic); cause != context.Canceled {
+		t.Errorf("context.Cause(imported) = %v, want %v", cause, context.Canceled)
+	}
+	if sig, ok := Signal(ic); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(imported) = %v, %v, want SIGTERM, true", sig, ok)
+	}
+}
+
+func TestImportStateDoneNotDiverted(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	Trigger(c, syscall.SIGTERM)
+	data, err := ExportState(c)
+	if err != nil {
+		t.Fatalf("ExportState = %v", err)
+	}
+
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	ic, istop, err := ImportState(context.Background(), data)
+	if err != nil {
+		t.Fatalf("ImportState(%s) = %v", data, err)
+	}
+	defer istop()
+	if n.ch != nil {
+		t.Errorf("ImportState registered %v for a context that was done", n.signals)
+	}
+	if sig, ok := Signal(ic); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(imported) = %v, %v, want SIGTERM, true", sig, ok)
+	}
+}
+
+func TestExportImportStateStopped(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGINT}, WithStopTrace())
+	stop()
+
+	data, err := ExportState(c)
+	if err != nil {
+		t.Fatalf("ExportState = %v", err)
+	}
+	ic, istop, err := ImportState(context.Background(), data)
+	if err != nil {
+		t.Fatalf("ImportState(%s) = %v", data, err)
+	}
+	defer istop()
+	if cause := context.Cause(ic); !errors.Is(cause, ErrStopped) {
+		t.Errorf("context.Cause(imported) = %v, want %v", cause, ErrStopped)
+	}
+	if got := CancelReason(ic); got != ReasonStop {
+		t.Errorf("CancelReason(imported) = %v, want %v", got, ReasonStop)
+	}
+}
+
+func TestExportImportStateLive(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGINT)
+	defer stop()
+
+	data, err := ExportState(c)
+	if err != nil {
+		t.Fatalf("ExportState = %v", err)
+	}
+	ic, istop, err := ImportState(context.Background(), data)
+	if err != nil {
+		t.Fatalf("ImportState(%s) = %v", data, err)
+	}
+	defer istop()
+	if err := ic.Err(); err != nil {
+		t.Errorf("imported context is done: %v", err)
+	}
+	if _, _, err := ImportState(context.Background(), []byte("{")); err == nil {
+		t.Errorf("ImportState of invalid data = nil error, want an error")
+	}
+}
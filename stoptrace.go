@@ -0,0 +1,58 @@
+package sigctx
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// ErrStopped is the cause of a context canceled by its stop function, when
+// the context records such a cause.
+var ErrStopped = errors.New("sigctx: stopped")
+
+// stopTraceDepth is the maximum number of frames recorded by WithStopTrace.
+const stopTraceDepth = 8
+
+// WithStopTrace makes the stop function record the stack of its caller. If
+// stop cancels the context, the cause returned by context.Cause is a
+// *StopTraceError, which helps to find out what shut a program down too
+// early. Recording the stack costs a few microseconds per call to stop.
+func WithStopTrace() Option {
+	return func(o *options) {
+		o.stopTrace = true
+	}
+}
+
+// A StopTraceError is the cause of a context created with WithStopTrace that
+// was canceled by its stop function. It matches ErrStopped with errors.Is.
+type StopTraceError struct {
+	// Stack holds the program counters of the stop call, innermost first.
+	Stack []uintptr
+}
+
+func newStopTraceError() *StopTraceError {
+	pcs := make([]uintptr, stopTraceDepth)
+	// Skip runtime.Callers, this function and signalCtx.stop.
+	n := runtime.Callers(3, pcs)
+	return &StopTraceError{Stack: pcs[:n]}
+}
+
+func (e *StopTraceError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrStopped.Error())
+	frames := runtime.CallersFrames(e.Stack)
+	for {
+		f, more := frames.Next()
+		// Skip the method value wrapper of signalCtx.stop.
+		if f.Function != "" && !strings.Contains(f.Function, ".(*signalCtx).") {
+			fmt.Fprintf(&b, "\n\t%s (%s:%d)", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+func (e *StopTraceError) Unwrap() error { return ErrStopped }
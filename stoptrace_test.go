@@ -0,0 +1,38 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithStopTrace(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithStopTrace())
+	stop()
+
+	cause := context.Cause(c)
+	var e *StopTraceError
+	if !errors.As(cause, &e) {
+		t.Fatalf("context.Cause(c) = %v, want a *StopTraceError", cause)
+	}
+	if !errors.Is(cause, ErrStopped) {
+		t.Errorf("errors.Is(%v, ErrStopped) = false, want true", cause)
+	}
+	if msg := cause.Error(); !strings.Contains(msg, "TestWithStopTrace") {
+		t.Errorf("cause %q does not contain the calling function name", msg)
+	}
+	if got := c.Err(); got != context.Canceled {
+		t.Errorf("c.Err() = %v, want %v", got, context.Canceled)
+	}
+}
+
+func TestWithoutStopTrace(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	stop()
+
+	if got := context.Cause(c); got != context.Canceled {
+		t.Errorf("context.Cause(c) = %v, want %v", got, context.Canceled)
+	}
+}
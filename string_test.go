@@ -0,0 +1,125 @@
+package sigctx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// namedCtx is a parent context with an arbitrary String result.
+type namedCtx struct {
+	context.Context
+	name string
+}
+
+func (c namedCtx) String() string { return c.name }
+
+func FuzzStringName(f *testing.F) {
+	for _, name := range []string{
+		"",
+		"x",
+		"WithCancel",
+		".WithCancel",
+		"context.Background.WithCancel",
+		"context.Background",
+		"(.WithCancel",
+	} {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		c := &signalCtx{
+			Context: namedCtx{Context: context.Background(), name: name},
+			signals: []os.Signal{syscall.SIGINT},
+		}
+		got := c.String()
+
+		trimmed := strings.TrimSuffix(name, ".WithCancel")
+		want := "signal.NotifyContext(" + trimmed + ", [interrupt])"
+		if got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+		if d, wd := parenDepth(got), parenDepth(trimmed); d != wd {
+			t.Errorf("String() = %q has parenthesis balance %d, want %d", got, d, wd)
+		}
+	})
+}
+
+// parenDepth returns the number of '(' minus the number of ')' in s.
+func parenDepth(s string) int {
+	return strings.Count(s, "(") - strings.Count(s, ")")
+}
+
+func TestStringDerived(t *testing.T) {
+	c, stop := NotifyContext(context.Background(), syscall.SIGINT)
+	defer stop()
+
+	tests := []struct {
+		ctx  context.Context
+		want string
+	}{
+		{
+			context.WithValue(c, requestIDKey{}, "req-1"),
+			"signal.NotifyContext(context.Background, [interrupt]).WithValue(sigctx.requestIDKey, req-1)",
+		},
+		{
+			func() context.Context {
+				ctx, cancel := context.WithCancel(c)
+				t.Cleanup(cancel)
+				return ctx
+			}(),
+			"signal.NotifyContext(context.Background, [interrupt]).WithCancel",
+		},
+	}
+	for _, tt := range tests {
+		got := fmt.Sprint(tt.ctx)
+		if got != tt.want {
+			t.Errorf("fmt.Sprint(ctx) = %q, want %q", got, tt.want)
+		}
+		if d := parenDepth(got); d != 0 {
+			t.Errorf("fmt.Sprint(ctx) = %q has parenthesis balance %d, want 0", got, d)
+		}
+	}
+}
+
+// plainCtx is a context without a String method.
+type plainCtx struct {
+	context.Context
+}
+
+func TestStringNotStringer(t *testing.T) {
+	c := &signalCtx{
+		Context: plainCtx{context.Background()},
+		signals: []os.Signal{syscall.SIGINT},
+	}
+	if want, got := "signal.NotifyContext(sigctx.plainCtx, [interrupt])", c.String(); got != want {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+}
+
+func TestStringParentNotStringer(t *testing.T) {
+	c, stop := NotifyContext(plainCtx{context.Background()}, syscall.SIGINT)
+	defer stop()
+	if want, got := "signal.NotifyContext(sigctx.plainCtx, [interrupt])", fmt.Sprint(c); got != want {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+}
+
+func TestStringCallerSliceReused(t *testing.T) {
+	signals := []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL}
+	c, stop := New(context.Background(), signals, WithNotifier(
+		func(chan<- os.Signal, ...os.Signal) {},
+		func(chan<- os.Signal) {},
+	))
+	defer stop()
+
+	want := fmt.Sprint(c)
+	for i := range signals {
+		signals[i] = syscall.SIGKILL
+	}
+	if got := fmt.Sprint(c); got != want {
+		t.Errorf("String() = %q after the caller reused its slice, want %q", got, want)
+	}
+}
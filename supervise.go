@@ -0,0 +1,106 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	superviseMinBackoff = 100 * time.Millisecond
+	superviseMaxBackoff = 5 * time.Second
+)
+
+// superviseBackoff returns how long SuperviseCmd waits before restarting a
+// command that exited failures times in a row. It is replaced in tests.
+var superviseBackoff = func(failures int) time.Duration {
+	d := superviseMinBackoff
+	for i := 1; i < failures && d < superviseMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > superviseMaxBackoff {
+		d = superviseMaxBackoff
+	}
+	return d
+}
+
+// SuperviseCmd runs the command returned by newCmd and restarts it, with a
+// new command from newCmd, whenever it exits, until one of the listed
+// signals arrives. The restarts back off exponentially from 100ms to 5s, and
+// the backoff starts over once a command ran for longer than that.
+//
+// When a signal arrives, SuperviseCmd forwards it to the running command,
+// waits for it to exit and returns nil. If the command does not exit within
+// DefaultDrainTimeout, or cannot receive the signal, as on Windows, it is
+// killed. Note that a command in the foreground process group of a terminal
+// also receives Ctrl+C directly; the forwarded signal then finds it exiting
+// or already gone, which is not an error.
+//
+// SuperviseCmd returns the error of Start if a command fails to start, and
+// the cause of parent if parent is done first, in which case the running
+// command is killed.
+func SuperviseCmd(parent context.Context, newCmd func() *exec.Cmd, signals ...os.Signal) error {
+	ctx, stop := NotifyContext(parent, signals...)
+	defer stop()
+
+	failures := 0
+	for {
+		cmd := newCmd()
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		started := time.Now()
+		exited := make(chan struct{})
+		go func() {
+			cmd.Wait()
+			close(exited)
+		}()
+
+		select {
+		case <-exited:
+		case <-ctx.Done():
+			shutdownCmd(ctx, cmd, exited)
+			return superviseErr(ctx)
+		}
+
+		if time.Since(started) > superviseMaxBackoff {
+			failures = 0
+		}
+		failures++
+		t := time.NewTimer(superviseBackoff(failures))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return superviseErr(ctx)
+		case <-t.C:
+		}
+	}
+}
+
+// shutdownCmd forwards the signal that canceled ctx to cmd, or kills it if
+// there is none, and waits for it to exit.
+func shutdownCmd(ctx context.Context, cmd *exec.Cmd, exited <-chan struct{}) {
+	sig, ok := Signal(ctx)
+	if !ok {
+		cmd.Process.Kill()
+	} else if err := cmd.Process.Signal(sig); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		cmd.Process.Kill()
+	}
+	t := time.NewTimer(DefaultDrainTimeout)
+	defer t.Stop()
+	select {
+	case <-exited:
+	case <-t.C:
+		cmd.Process.Kill()
+		<-exited
+	}
+}
+
+func superviseErr(ctx context.Context) error {
+	if _, ok := Signal(ctx); ok {
+		return nil
+	}
+	return context.Cause(ctx)
+}
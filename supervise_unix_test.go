@@ -0,0 +1,86 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// quickCmd returns a command that exits right away: the test binary
+// running no tests.
+func quickCmd() *exec.Cmd {
+	return exec.Command(os.Args[0], "-test.run=^$")
+}
+
+func withBackoff(t *testing.T, d time.Duration) {
+	t.Helper()
+	orig := superviseBackoff
+	superviseBackoff = func(int) time.Duration { return d }
+	t.Cleanup(func() { superviseBackoff = orig })
+}
+
+func TestSuperviseCmd(t *testing.T) {
+	withBackoff(t, time.Millisecond)
+
+	var starts atomic.Int32
+	restarted := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- SuperviseCmd(context.Background(), func() *exec.Cmd {
+			if starts.Add(1) == 3 {
+				close(restarted)
+			}
+			return quickCmd()
+		}, syscall.SIGUSR1)
+	}()
+
+	select {
+	case <-restarted:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for the command to be restarted")
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("SuperviseCmd() = %v, want nil", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for SuperviseCmd to return")
+	}
+}
+
+func TestSuperviseCmdParent(t *testing.T) {
+	withBackoff(t, time.Hour)
+
+	parent, cancel := context.WithCancelCause(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- SuperviseCmd(parent, quickCmd, syscall.SIGUSR1)
+	}()
+	cancel(errParentGone)
+	select {
+	case err := <-done:
+		if err != errParentGone {
+			t.Errorf("SuperviseCmd() = %v, want %v", err, errParentGone)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for SuperviseCmd to return")
+	}
+}
+
+func TestSuperviseCmdStartError(t *testing.T) {
+	err := SuperviseCmd(context.Background(), func() *exec.Cmd {
+		return exec.Command("/nonexistent/sigctx-supervised")
+	}, syscall.SIGUSR1)
+	if err == nil {
+		t.Errorf("SuperviseCmd() = nil, want the error of Start")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package sigctx
+
+// WithSuspendHook calls onSuspend, without canceling the context, each time
+// the process is asked to suspend. This platform has no such notification,
+// so the hook is never called.
+func WithSuspendHook(onSuspend func()) Option {
+	return func(o *options) {}
+}
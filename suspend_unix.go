@@ -0,0 +1,34 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"os"
+	"syscall"
+)
+
+// WithSuspendHook calls onSuspend, without canceling the context, each time
+// the process is asked to suspend. On Unix this is SIGTSTP, sent by Ctrl+Z in
+// a terminal. Catching SIGTSTP replaces its default behavior, so the process
+// is not actually suspended unless onSuspend does it, for example by sending
+// itself SIGSTOP. On other platforms the hook is never called.
+func WithSuspendHook(onSuspend func()) Option {
+	return func(o *options) {
+		ch := make(chan os.Signal, 1)
+		o.setups = append(o.setups, func(c *signalCtx) {
+			c.opts.notify(ch, syscall.SIGTSTP)
+		})
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			defer c.opts.stopNotify(ch)
+			for {
+				select {
+				case <-ch:
+					onSuspend()
+				case <-c.Done():
+					return
+				}
+			}
+		})
+	}
+}
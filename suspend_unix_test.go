@@ -0,0 +1,30 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithSuspendHook(t *testing.T) {
+	suspended := make(chan struct{}, 1)
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, WithSuspendHook(func() {
+		suspended <- struct{}{}
+	}))
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGTSTP)
+	select {
+	case <-suspended:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the suspend hook")
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("c.Err() = %v after SIGTSTP, want nil", err)
+	}
+}
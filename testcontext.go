@@ -0,0 +1,24 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// NotifyContextT returns a signal context rooted at context.Background for
+// use in tests, typically given a *testing.T or *testing.B. It listens for
+// the given signals without registering them with the signal package, so
+// that it does not catch the signals of the test binary; use Trigger to
+// cancel it as if one of them had arrived. Its stop function is registered
+// with t.Cleanup, so that the test never leaks it.
+//
+// t is not a testing.TB so that this package does not import the testing
+// package.
+func NotifyContextT(t interface{ Cleanup(func()) }, signals ...os.Signal) context.Context {
+	ctx, stop := New(context.Background(), signals, WithNotifier(
+		func(chan<- os.Signal, ...os.Signal) {},
+		func(chan<- os.Signal) {},
+	))
+	t.Cleanup(stop)
+	return ctx
+}
@@ -0,0 +1,27 @@
+package sigctx
+
+import (
+	"context"
+	"syscall"
+	"testing"
+)
+
+func TestNotifyContextT(t *testing.T) {
+	var c context.Context
+	t.Run("trigger", func(t *testing.T) {
+		c = NotifyContextT(t, syscall.SIGTERM)
+		if err := c.Err(); err != nil {
+			t.Fatalf("c.Err() = %v before Trigger, want nil", err)
+		}
+		if err := Trigger(c, syscall.SIGTERM); err != nil {
+			t.Fatalf("Trigger(c) = %v", err)
+		}
+		if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+			t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+		}
+	})
+	// The subtest's cleanup stopped the context.
+	if _, ok := <-Events(c); ok {
+		t.Errorf("expected the context to be stopped by the test cleanup")
+	}
+}
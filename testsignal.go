@@ -0,0 +1,17 @@
+package sigctx
+
+// TestSignal is an os.Signal with an arbitrary name and number. It is test
+// support: together with WithNotifier, it lets packages test their signal
+// handling on any platform without sending signals to the process.
+type TestSignal struct {
+	Name string
+	Num  int
+}
+
+// Signal implements os.Signal.
+func (s TestSignal) Signal() {}
+
+func (s TestSignal) String() string { return s.Name }
+
+// Number returns s.Num.
+func (s TestSignal) Number() int { return s.Num }
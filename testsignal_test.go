@@ -0,0 +1,82 @@
+package sigctx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeNotifier records the channel registered through WithNotifier so that
+// tests can deliver signals to it.
+type fakeNotifier struct {
+	mu      sync.Mutex
+	ch      chan<- os.Signal
+	signals []os.Signal
+	stopped bool
+}
+
+func (n *fakeNotifier) option() Option {
+	return WithNotifier(n.notify, n.stop)
+}
+
+func (n *fakeNotifier) notify(c chan<- os.Signal, sig ...os.Signal) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.ch = c
+	n.signals = append(n.signals, sig...)
+}
+
+func (n *fakeNotifier) stop(c chan<- os.Signal) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stopped = true
+}
+
+// send delivers sig unless the channel was unregistered or is full. It
+// reports whether sig was delivered.
+func (n *fakeNotifier) send(sig os.Signal) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch == nil || n.stopped {
+		return false
+	}
+	select {
+	case n.ch <- sig:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestTestSignal(t *testing.T) {
+	sig := TestSignal{Name: "test signal", Num: 42}
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{sig}, n.option())
+	defer stop()
+
+	if want, got := "signal.NotifyContext(context.Background, [test signal])", fmt.Sprint(c); want != got {
+		t.Errorf("c.String() = %q, want %q", got, want)
+	}
+
+	n.send(sig)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done after %v", sig)
+	}
+	got, ok := Signal(c)
+	if !ok || got != sig {
+		t.Fatalf("Signal(c) = %v, %v, want %v, true", got, ok, sig)
+	}
+	if num := got.(interface{ Number() int }).Number(); num != 42 {
+		t.Errorf("Number() = %d, want 42", num)
+	}
+
+	stop()
+	if !n.stopped {
+		t.Errorf("expected stop to unregister through the notifier")
+	}
+}
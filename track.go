@@ -0,0 +1,53 @@
+package sigctx
+
+import "context"
+
+// tracked is a child context recorded by Track.
+type tracked struct {
+	cancel context.CancelCauseFunc
+}
+
+// Track returns a child of ctx, like context.WithCancel, and records it in
+// the signal context of ctx so that CancelAll can cancel it with a specific
+// cause. Calling cancel forgets the child, so it should be called as soon as
+// the child is no longer used. If ctx has no signal context, the child is not
+// recorded.
+func Track(ctx context.Context) (context.Context, context.CancelFunc) {
+	child, cancel := context.WithCancelCause(ctx)
+	c, ok := fromContext(ctx)
+	if !ok {
+		return child, func() { cancel(nil) }
+	}
+	t := &tracked{cancel: cancel}
+	c.mu.Lock()
+	if c.tracked == nil {
+		c.tracked = make(map[*tracked]struct{})
+	}
+	c.tracked[t] = struct{}{}
+	c.mu.Unlock()
+	return child, func() {
+		c.mu.Lock()
+		delete(c.tracked, t)
+		c.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// CancelAll cancels every child of the signal context of ctx returned by
+// Track and not canceled yet, with the given cause, which context.Cause
+// returns for them. The signal context itself is not canceled. CancelAll
+// returns ErrNotSignalContext if ctx has no signal context.
+func CancelAll(ctx context.Context, cause error) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	c.mu.Lock()
+	children := c.tracked
+	c.tracked = nil
+	c.mu.Unlock()
+	for t := range children {
+		t.cancel(cause)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+var errMaintenance = errors.New("maintenance")
+
+func TestCancelAll(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+
+	a, cancelA := Track(c)
+	defer cancelA()
+	b, cancelB := Track(context.WithValue(c, requestIDKey{}, "req-1"))
+	defer cancelB()
+
+	if err := CancelAll(c, errMaintenance); err != nil {
+		t.Fatalf("CancelAll = %v, want nil", err)
+	}
+	for i, child := range []context.Context{a, b} {
+		select {
+		case <-child.Done():
+		default:
+			t.Fatalf("child %d is not done after CancelAll", i)
+		}
+		if got := context.Cause(child); got != errMaintenance {
+			t.Errorf("context.Cause(child %d) = %v, want %v", i, got, errMaintenance)
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("c.Err() = %v after CancelAll, want nil", err)
+	}
+}
+
+func TestTrackCancel(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+
+	a, cancelA := Track(c)
+	cancelA()
+	if got := context.Cause(a); got != context.Canceled {
+		t.Errorf("context.Cause(a) = %v, want %v", got, context.Canceled)
+	}
+	if n := len(c.(*signalCtx).tracked); n != 0 {
+		t.Errorf("%d children still tracked after cancel", n)
+	}
+
+	if err := CancelAll(context.Background(), errMaintenance); err != ErrNotSignalContext {
+		t.Errorf("CancelAll(context.Background()) = %v, want %v", err, ErrNotSignalContext)
+	}
+}
@@ -0,0 +1,36 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// ErrNotSignalContext is returned by functions that require a context
+// created by this package, or derived from one, when given another context.
+var ErrNotSignalContext = errors.New("sigctx: not a signal context")
+
+// Trigger cancels the signal context of ctx exactly as if sig had been
+// received, without sending a signal to the process: Signal reports sig, a
+// Received event is published and, for contexts that record it, such as
+// those returned by NotifyContextCause, context.Cause returns a
+// *SignalError.
+// Options that hold back cancellation, such as WithBurst, do not apply.
+//
+// Trigger returns ErrNotSignalContext if ctx was not created by this package,
+// and the cause of ctx if it is already done.
+func Trigger(ctx context.Context, sig os.Signal) error {
+	c, ok := fromContext(ctx)
+	if !ok {
+		return ErrNotSignalContext
+	}
+	if c.Err() != nil {
+		return context.Cause(c)
+	}
+	c.publish(Event{Type: Received, Signal: sig, Time: time.Now()})
+	if !c.cancelSignal(sig) {
+		return context.Cause(c)
+	}
+	return nil
+}
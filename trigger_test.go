@@ -0,0 +1,56 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+)
+
+func TestTrigger(t *testing.T) {
+	c, stop := NotifyContextCause(context.Background(), syscall.SIGTERM)
+	defer stop()
+	events := Events(c)
+
+	if err := Trigger(c, syscall.SIGTERM); err != nil {
+		t.Fatalf("Trigger = %v, want nil", err)
+	}
+	select {
+	case <-c.Done():
+	default:
+		t.Fatalf("expected Trigger to cancel the context")
+	}
+	if sig, ok := Signal(c); !ok || sig != syscall.SIGTERM {
+		t.Errorf("Signal(c) = %v, %v, want %v, true", sig, ok, syscall.SIGTERM)
+	}
+	if cause := context.Cause(c); !errors.Is(cause, &SignalError{Signal: syscall.SIGTERM}) {
+		t.Errorf("context.Cause(c) = %v, want SIGTERM", cause)
+	}
+	if got := c.Err(); got != context.Canceled {
+		t.Errorf("c.Err() = %v, want %v", got, context.Canceled)
+	}
+
+	stop()
+	var got []EventType
+	for e := range events {
+		got = append(got, e.Type)
+	}
+	if want := []EventType{Received, Canceled, Stopped}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestTriggerErrors(t *testing.T) {
+	if err := Trigger(context.Background(), syscall.SIGTERM); err != ErrNotSignalContext {
+		t.Errorf("Trigger(context.Background()) = %v, want %v", err, ErrNotSignalContext)
+	}
+
+	c, stop := NotifyContext(context.Background(), syscall.SIGTERM)
+	stop()
+	if err := Trigger(c, syscall.SIGTERM); err == nil {
+		t.Errorf("Trigger on a stopped context = nil, want an error")
+	}
+	if _, ok := Signal(c); ok {
+		t.Errorf("expected Trigger on a stopped context not to record the signal")
+	}
+}
@@ -0,0 +1,28 @@
+package sigctx
+
+import "time"
+
+// WithRegistrationTTL releases the signal registration d after the context
+// is created, even if the context is still live, so that short tasks do not
+// hold on to the process-wide signal handlers. Once released, the listed
+// signals regain their previous behavior and no longer cancel the context;
+// the parent and the stop function still do. A warning is logged when the
+// registration is released, to the logger set with WithLogger, or the
+// default logger.
+//
+// Unlike WithMaxLifetime, which also stops watching the context,
+// WithRegistrationTTL only affects the registration.
+func WithRegistrationTTL(d time.Duration) Option {
+	return func(o *options) {
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			t := time.NewTimer(d)
+			defer t.Stop()
+			select {
+			case <-c.Done():
+			case <-t.C:
+				c.opts.stopNotify(c.in)
+				c.opts.warnLogger().Warn("sigctx: signal registration expired", "ttl", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package sigctx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithRegistrationTTL(t *testing.T) {
+	var n fakeNotifier
+	var h recordingHandler
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(),
+		WithRegistrationTTL(10*time.Millisecond), WithLogger(slog.New(&h)))
+	defer stop()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		n.mu.Lock()
+		stopped := n.stopped
+		n.mu.Unlock()
+		if stopped {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the registration to be released")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n.send(syscall.SIGTERM) {
+		t.Errorf("signal delivered after the registration was released")
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("c.Err() = %v after the TTL, want nil", err)
+	}
+	stop()
+	if lvl, ok := h.levels()["sigctx: signal registration expired"]; !ok || lvl != slog.LevelWarn {
+		t.Errorf("expiry logged at %v, %v, want %v", lvl, ok, slog.LevelWarn)
+	}
+}
+
+func TestWithRegistrationTTLStop(t *testing.T) {
+	var n fakeNotifier
+	c, stop := New(context.Background(), []os.Signal{syscall.SIGTERM}, n.option(), WithRegistrationTTL(time.Hour))
+	n.send(syscall.SIGTERM)
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for context to be done before the TTL")
+	}
+	stop()
+}
@@ -0,0 +1,42 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// UncatchableSignals returns the signals that cannot be caught on this
+// platform, such as SIGKILL and SIGSTOP on Unix. Listing them in NotifyContext
+// has no effect. The result is never nil and may be modified by the caller.
+func UncatchableSignals() []os.Signal {
+	return append([]os.Signal{}, uncatchableSignals...)
+}
+
+func isUncatchable(sig os.Signal) bool {
+	for _, s := range uncatchableSignals {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrUncatchable is wrapped by the error NotifyContextChecked returns when
+// given a signal that cannot be caught.
+var ErrUncatchable = errors.New("sigctx: signal cannot be caught")
+
+// NotifyContextChecked is like NotifyContext, but returns an error wrapping
+// ErrUncatchable, and no context, if one of the signals is listed by
+// UncatchableSignals, rather than quietly never canceling the context for
+// it.
+func NotifyContextChecked(parent context.Context, signals ...os.Signal) (ctx context.Context, stop context.CancelFunc, err error) {
+	for _, sig := range signals {
+		if isUncatchable(sig) {
+			return nil, nil, fmt.Errorf("%w on this platform: %v", ErrUncatchable, sig)
+		}
+	}
+	ctx, stop = newSignalCtx(parent, signals, nil)
+	return ctx, stop, nil
+}
@@ -0,0 +1,42 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUncatchableSignalsStable(t *testing.T) {
+	a := UncatchableSignals()
+	if a == nil {
+		t.Fatalf("UncatchableSignals() = nil, want a non-nil slice")
+	}
+	if len(a) > 0 {
+		a[0] = nil
+	}
+	if b := UncatchableSignals(); !reflect.DeepEqual(b, uncatchableSignals) {
+		t.Errorf("UncatchableSignals() = %v after modifying a previous result, want %v", b, uncatchableSignals)
+	}
+}
+
+func TestNotifyContextChecked(t *testing.T) {
+	_, _, err := NotifyContextChecked(context.Background(), os.Interrupt, os.Kill)
+	if !errors.Is(err, ErrUncatchable) {
+		t.Fatalf("NotifyContextChecked(SIGKILL) = %v, want an error wrapping %v", err, ErrUncatchable)
+	}
+	if !strings.Contains(err.Error(), os.Kill.String()) {
+		t.Errorf("error %q does not name %v", err, os.Kill)
+	}
+
+	c, stop, err := NotifyContextChecked(context.Background(), os.Interrupt)
+	if err != nil {
+		t.Fatalf("NotifyContextChecked(os.Interrupt) = %v", err)
+	}
+	stop()
+	if c.Err() == nil {
+		t.Errorf("expected stop to cancel the context")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"os"
+	"syscall"
+)
+
+var uncatchableSignals = []os.Signal{syscall.SIGKILL, syscall.SIGSTOP}
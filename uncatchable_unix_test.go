@@ -0,0 +1,20 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sigctx
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestUncatchableSignals(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGKILL, syscall.SIGSTOP} {
+		if !isUncatchable(sig) {
+			t.Errorf("%v is not in UncatchableSignals() = %v", sig, UncatchableSignals())
+		}
+	}
+	if isUncatchable(syscall.SIGINT) {
+		t.Errorf("SIGINT is in UncatchableSignals() = %v", UncatchableSignals())
+	}
+}
@@ -0,0 +1,9 @@
+package sigctx
+
+import (
+	"os"
+	"syscall"
+)
+
+// Windows terminates a process without notifying it when it is killed.
+var uncatchableSignals = []os.Signal{syscall.SIGKILL}
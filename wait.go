@@ -0,0 +1,66 @@
+package sigctx
+
+import (
+	"context"
+	"os"
+)
+
+// WaitWithContext blocks until either ctx, typically a signal context, or
+// work, the context of some unit of work, is done. It returns nil if work
+// finished, and context.Cause(ctx), such as a *SignalError, if ctx was done
+// first. If both are already done, work wins and WaitWithContext returns
+// nil.
+func WaitWithContext(ctx context.Context, work context.Context) error {
+	select {
+	case <-work.Done():
+		return nil
+	case <-ctx.Done():
+		select {
+		case <-work.Done():
+			return nil
+		default:
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// WithSignals is like NotifyContext, but instead of a stop function it
+// returns a wait function that blocks until the context is done, then
+// unregisters the signals. It returns context.Cause(ctx), a *SignalError
+// that wraps context.Canceled, if a signal canceled the context, and nil if
+// the parent did. This lets wait be passed directly to the Go method of an
+// errgroup.Group created with parent, so that a signal cancels the group
+// while a failing goroutine does not make wait report an error of its own:
+//
+//	g, gctx := errgroup.WithContext(ctx)
+//	ctx, wait := sigctx.WithSignals(gctx, os.Interrupt)
+//	g.Go(wait)
+//
+// Wait must be called, or the signals stay registered after the context is
+// done.
+func WithSignals(parent context.Context, signals ...os.Signal) (ctx context.Context, wait func() error) {
+	c, stop := newSignalCtx(parent, signals, []Option{withSignalCause})
+	return c, func() error {
+		<-c.Done()
+		stop()
+		if _, ok := Signal(c); ok {
+			return context.Cause(c)
+		}
+		return nil
+	}
+}
+
+// WaitForSignal blocks until one of the listed signals arrives, or any
+// signal if none is listed, and returns it. The signals are only diverted
+// while WaitForSignal waits: it unregisters them before returning. This
+// suits a main function that has nothing to cancel and only waits to exit:
+//
+//	go serve()
+//	sig := sigctx.WaitForSignal(os.Interrupt, syscall.SIGTERM)
+//	log.Printf("exiting on %v", sig)
+func WaitForSignal(signals ...os.Signal) os.Signal {
+	ch := make(chan os.Signal, 1)
+	notifyFunc(ch, signals...)
+	defer stopFunc(ch)
+	return <-ch
+}
@@ -0,0 +1,105 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWaitWithContextWork(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+
+	work, done := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, done)
+	if err := WaitWithContext(c, work); err != nil {
+		t.Errorf("WaitWithContext() = %v, want nil", err)
+	}
+	if c.Err() != nil {
+		t.Errorf("c.Err() = %v, want nil", c.Err())
+	}
+}
+
+func TestWaitWithContextSignal(t *testing.T) {
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt})
+	defer stop()
+
+	work, done := context.WithCancel(context.Background())
+	defer done()
+	time.AfterFunc(10*time.Millisecond, func() { Trigger(c, syscall.SIGTERM) })
+	err := WaitWithContext(c, work)
+	var serr *SignalError
+	if !errors.As(err, &serr) || serr.Signal != syscall.SIGTERM {
+		t.Errorf("WaitWithContext() = %v, want a *SignalError for %v", err, syscall.SIGTERM)
+	}
+}
+
+func TestWithSignals(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	c, wait := WithSignals(context.Background(), syscall.SIGTERM)
+	done := make(chan error, 1)
+	go func() { done <- wait() }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("wait returned %v before any signal", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	n.send(syscall.SIGTERM)
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("wait() = %v, want an error wrapping %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for wait to return after SIGTERM")
+	}
+	if c.Err() == nil {
+		t.Errorf("expected the context to be canceled")
+	}
+	if !n.stopped {
+		t.Errorf("expected wait to unregister the signals")
+	}
+}
+
+func TestWithSignalsParent(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	_, wait := WithSignals(parent, os.Interrupt)
+	cancel()
+	if err := wait(); err != nil {
+		t.Errorf("wait() = %v after the parent was canceled, want nil", err)
+	}
+}
+
+func TestWaitForSignal(t *testing.T) {
+	var n fakeNotifier
+	oldNotify, oldStop := notifyFunc, stopFunc
+	notifyFunc, stopFunc = n.notify, n.stop
+	defer func() { notifyFunc, stopFunc = oldNotify, oldStop }()
+
+	got := make(chan os.Signal, 1)
+	go func() { got <- WaitForSignal(syscall.SIGINT, syscall.SIGTERM) }()
+	for !n.send(syscall.SIGINT) {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case sig := <-got:
+		if sig != syscall.SIGINT {
+			t.Errorf("WaitForSignal() = %v, want %v", sig, syscall.SIGINT)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for WaitForSignal to return")
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.stopped {
+		t.Errorf("expected WaitForSignal to unregister the signals")
+	}
+}
@@ -0,0 +1,58 @@
+package sigctx
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// ErrFileChanged is the cause of a context canceled because the file given to
+// WithWatchFile was modified or deleted.
+var ErrFileChanged = errors.New("sigctx: watched file changed")
+
+// fileWatchInterval is how often WithWatchFile checks the file.
+var fileWatchInterval = time.Second
+
+// statFile is os.Stat, replaced in tests.
+var statFile = os.Stat
+
+// WithWatchFile makes the context also cancel, with cause ErrFileChanged,
+// when the named file is modified, created or deleted after the context was
+// created. The file is polled for changes in its modification time and size,
+// so a change may take up to a second to be noticed, and a change that
+// preserves both is missed.
+func WithWatchFile(path string) Option {
+	return func(o *options) {
+		fi, err := statFile(path)
+		o.watchers = append(o.watchers, func(c *signalCtx) {
+			c.watchFile(path, fi, err)
+		})
+	}
+}
+
+func (c *signalCtx) watchFile(path string, fi os.FileInfo, err error) {
+	t := time.NewTicker(fileWatchInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.Done():
+			return
+		case <-t.C:
+		}
+		nfi, nerr := statFile(path)
+		if fileChanged(fi, err, nfi, nerr) {
+			c.cancel(ErrFileChanged)
+			return
+		}
+	}
+}
+
+func fileChanged(fi os.FileInfo, err error, nfi os.FileInfo, nerr error) bool {
+	if (err == nil) != (nerr == nil) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+	return !fi.ModTime().Equal(nfi.ModTime()) || fi.Size() != nfi.Size()
+}
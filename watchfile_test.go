@@ -0,0 +1,86 @@
+package sigctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setFileWatchInterval(t *testing.T, d time.Duration) {
+	old := fileWatchInterval
+	fileWatchInterval = d
+	t.Cleanup(func() { fileWatchInterval = old })
+}
+
+func TestWithWatchFile(t *testing.T) {
+	setFileWatchInterval(t, 10*time.Millisecond)
+	path := filepath.Join(t.TempDir(), "shutdown")
+	if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithWatchFile(path))
+	defer stop()
+
+	if err := os.WriteFile(path, []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.Done():
+		if got := context.Cause(c); !errors.Is(got, ErrFileChanged) {
+			t.Errorf("context.Cause(c) = %v, want %v", got, ErrFileChanged)
+		}
+		if got := c.Err(); got != context.Canceled {
+			t.Errorf("c.Err() = %v, want %v", got, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for context to be done after modifying the file")
+	}
+}
+
+func TestWithWatchFileDeleted(t *testing.T) {
+	setFileWatchInterval(t, 10*time.Millisecond)
+	path := filepath.Join(t.TempDir(), "shutdown")
+	if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithWatchFile(path))
+	defer stop()
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.Done():
+		if got := context.Cause(c); !errors.Is(got, ErrFileChanged) {
+			t.Errorf("context.Cause(c) = %v, want %v", got, ErrFileChanged)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for context to be done after deleting the file")
+	}
+}
+
+func TestWithWatchFileStop(t *testing.T) {
+	setFileWatchInterval(t, time.Millisecond)
+	var stats int32
+	statFile = func(name string) (os.FileInfo, error) {
+		atomic.AddInt32(&stats, 1)
+		return os.Stat(name)
+	}
+	t.Cleanup(func() { statFile = os.Stat })
+
+	_, stop := New(context.Background(), []os.Signal{os.Interrupt}, WithWatchFile(filepath.Join(t.TempDir(), "missing")))
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	n := atomic.LoadInt32(&stats)
+	time.Sleep(10 * time.Millisecond)
+	if got := atomic.LoadInt32(&stats); got != n {
+		t.Errorf("file was polled %d times after stop returned", got-n)
+	}
+}